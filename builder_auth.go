@@ -15,6 +15,8 @@ type BuilderAuth struct {
 	APIKey     string
 	Secret     string
 	Passphrase string
+
+	clock Clock
 }
 
 func NewBuilderAuth(apiKey, secret, passphrase string) *BuilderAuth {
@@ -25,9 +27,18 @@ func NewBuilderAuth(apiKey, secret, passphrase string) *BuilderAuth {
 	}
 }
 
+// SetClock 设置签名时间戳所使用的时钟（例如 ClockSync）；nil 表示使用本地时间。
+func (b *BuilderAuth) SetClock(clock Clock) {
+	b.clock = clock
+}
+
 // Headers 返回认证头。
 func (b *BuilderAuth) Headers(method, path string, body []byte) (map[string]string, error) {
-	timestamp := time.Now().Unix()
+	now := time.Now
+	if b.clock != nil {
+		now = b.clock.Now
+	}
+	timestamp := now().Unix()
 	signature := b.buildHmacSignature(b.Secret, fmt.Sprintf("%d", timestamp), method, path, string(body))
 
 	return map[string]string{
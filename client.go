@@ -2,6 +2,7 @@
 package polymarket
 
 import (
+	"context"
 	"errors"
 
 	"github.com/dcsunny/polymarket-sdk/internal/httpx"
@@ -12,10 +13,16 @@ type SDK struct {
 	cfg Config
 
 	REST   *RESTClient
+	Data   *DataClient
 	CLOB   *CLOBClient
 	WSS    *WSSClient
 	RTDS   *RTDSClient
 	Wallet *WalletModule
+
+	// Clock 仅在 Config.ClockSync 启用时非空。
+	Clock *ClockSync
+
+	stopClock context.CancelFunc
 }
 
 // New 创建一个应用了默认配置的新 SDK 客户端。
@@ -26,25 +33,52 @@ func New(cfg Config) (*SDK, error) {
 		return nil, errors.New("base urls are required")
 	}
 
-	restHTTP, err := httpx.New(cfg.BaseURL, cfg.Timeout, cfg.Proxy, cfg.UserAgent, cfg.Debug)
+	restHTTP, err := httpx.NewWithOptions(cfg.BaseURL, cfg.httpOptions(cfg.GammaHTTP))
+	if err != nil {
+		return nil, err
+	}
+	clobHTTP, err := httpx.NewWithOptions(cfg.CLOBBaseURL, cfg.httpOptions(cfg.CLOBHTTP))
 	if err != nil {
 		return nil, err
 	}
-	clobHTTP, err := httpx.New(cfg.CLOBBaseURL, cfg.Timeout, cfg.Proxy, cfg.UserAgent, cfg.Debug)
+	dataHTTP, err := httpx.NewWithOptions(cfg.DataAPIURL, cfg.httpOptions(cfg.DataHTTP))
 	if err != nil {
 		return nil, err
 	}
+	restHTTP.SetMetrics("gamma", cfg.Metrics)
+	clobHTTP.SetMetrics("clob", cfg.Metrics)
+	dataHTTP.SetMetrics("data", cfg.Metrics)
 
 	sdk := &SDK{cfg: cfg}
 	sdk.REST = NewRESTClient(restHTTP)
+	sdk.Data = NewDataClient(dataHTTP)
 	sdk.CLOB = NewCLOBClient(clobHTTP, cfg)
 	sdk.WSS = NewWSSClient(cfg)
 	sdk.RTDS = NewRTDSClient(cfg)
 	sdk.Wallet = NewWalletModule(cfg)
 
+	if cfg.ClockSync {
+		sdk.Clock = NewClockSync(sdk.CLOB, ClockSyncOptions{Interval: cfg.ClockSyncInterval})
+		sdk.CLOB.SetClock(sdk.Clock)
+		sdk.Wallet.clock = sdk.Clock
+		// 首次同步在后台进行，失败时退化为本地时间（偏移为 0），等待下一轮刷新；Close 时停止。
+		ctx, cancel := context.WithCancel(context.Background())
+		sdk.stopClock = cancel
+		go func() { _ = sdk.Clock.Start(ctx) }()
+	}
+
 	return sdk, nil
 }
 
+// Close 停止时钟同步的后台刷新，并关闭 WSS 与 RTDS 连接。
+func (s *SDK) Close() error {
+	if s.stopClock != nil {
+		s.stopClock()
+		s.Clock.Stop()
+	}
+	return errors.Join(s.WSS.Close(), s.RTDS.Close())
+}
+
 // Config 返回 SDK 配置的副本。
 func (s *SDK) Config() Config {
 	return s.cfg
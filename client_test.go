@@ -0,0 +1,50 @@
+// client_test.go 模块
+package polymarket_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pm "github.com/dcsunny/polymarket-sdk"
+	"github.com/dcsunny/polymarket-sdk/polymarkettest"
+)
+
+func TestClockSyncCorrectsL2Skew(t *testing.T) {
+	srv := polymarkettest.NewServer(polymarkettest.Options{ClockOffset: -2 * time.Minute})
+	defer srv.Close()
+	ctx := context.Background()
+
+	plain, err := pm.New(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer plain.Close()
+	if _, err := plain.CLOB.GetAPIKeys(ctx); !errors.Is(err, pm.ErrUnauthorized) {
+		t.Fatalf("skewed request err = %v, want ErrUnauthorized", err)
+	}
+
+	cfg := srv.Config()
+	cfg.ClockSync = true
+	sdk, err := pm.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for sdk.Clock.LastSync().IsZero() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout waiting for clock sync")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if off := sdk.Clock.Offset(); off > -119*time.Second || off < -121*time.Second {
+		t.Fatalf("offset = %v, want about -2m", off)
+	}
+	if _, err := sdk.CLOB.GetAPIKeys(ctx); err != nil {
+		t.Fatalf("synced request: %v", err)
+	}
+	if err := sdk.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
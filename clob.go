@@ -15,6 +15,7 @@ import (
 
 	"github.com/dcsunny/polymarket-sdk/internal/auth"
 	"github.com/dcsunny/polymarket-sdk/internal/httpx"
+	"github.com/dcsunny/polymarket-sdk/internal/metrics"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -41,6 +42,9 @@ type CLOBClient struct {
 	tickSizeCache map[string]string
 	negRiskCache  map[string]bool
 	feeRateCache  map[string]int
+
+	metrics Metrics
+	clock   Clock
 }
 
 func NewCLOBClient(http *httpx.Client, cfg Config) *CLOBClient {
@@ -69,6 +73,8 @@ func NewCLOBClient(http *httpx.Client, cfg Config) *CLOBClient {
 		tickSizeCache: make(map[string]string),
 		negRiskCache:  make(map[string]bool),
 		feeRateCache:  make(map[string]int),
+		metrics:       metrics.OrNop(cfg.Metrics),
+		clock:         systemClock{},
 	}
 
 	if cfg.BuilderAPIKey != "" && cfg.BuilderAPISecret != "" && cfg.BuilderPassphrase != "" {
@@ -98,11 +104,33 @@ func (c *CLOBClient) SetAPICredentials(key, secret, passphrase string) {
 	c.passphrase = passphrase
 }
 
+// SetClock 设置签名与 GTD 过期时间计算所使用的时钟（例如 ClockSync）；nil 表示使用本地时间。
+func (c *CLOBClient) SetClock(clock Clock) {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	c.clock = clock
+	if c.builderAuth != nil {
+		c.builderAuth.SetClock(clock)
+	}
+}
+
+// Now 返回客户端时钟的当前时间（启用 ClockSync 时为校正后的服务器时间）。
+func (c *CLOBClient) Now() time.Time {
+	return c.clock.Now()
+}
+
+// GTDExpiration 返回 ttl 后过期的 GTD 订单 expiration（unix 秒字符串）。
+// 已包含服务端 1 分钟的安全阈值（GTDSecurityThreshold），并使用客户端时钟。
+func (c *CLOBClient) GTDExpiration(ttl time.Duration) string {
+	return strconv.FormatInt(c.Now().Add(GTDSecurityThreshold+ttl).Unix(), 10)
+}
+
 func (c *CLOBClient) l2Headers(method, path, body string) (map[string]string, error) {
 	if c.address == "" || c.apiKey == "" || c.apiSecret == "" || c.passphrase == "" {
 		return nil, errors.New("missing L2 credentials")
 	}
-	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	timestamp := strconv.FormatInt(c.clock.Now().Unix(), 10)
 	signature := auth.L2Signature(c.apiSecret, timestamp, method, path, body)
 	return map[string]string{
 		"POLY_ADDRESS":    c.address,
@@ -117,7 +145,7 @@ func (c *CLOBClient) l1Headers(nonce int) (map[string]string, error) {
 	if c.address == "" || c.privateKey == "" {
 		return nil, errors.New("missing address or private key")
 	}
-	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	timestamp := strconv.FormatInt(c.clock.Now().Unix(), 10)
 	sig, err := auth.ClobAuthSignature(c.privateKey, c.address, timestamp, nonce, c.cfg.ChainID)
 	if err != nil {
 		return nil, err
@@ -194,18 +222,10 @@ func (c *CLOBClient) DeleteAPIKey(ctx context.Context) error {
 
 // GetActiveOrders 返回活跃订单，自动分页直到结束。
 func (c *CLOBClient) GetActiveOrders(ctx context.Context, req *GetActiveOrdersRequest) ([]*OpenOrder, error) {
-	nextCursor := InitialCursor
-	var all []*OpenOrder
-
-	for nextCursor != EndCursor {
-		resp, err := c.GetActiveOrdersPage(ctx, req, nextCursor)
-		if err != nil {
-			return nil, err
-		}
-		all = append(all, resp.Data...)
-		nextCursor = resp.NextCursor
+	all, err := c.ActiveOrdersPaginator(req, CursorOptions{}).Collect(ctx)
+	if err != nil {
+		return nil, err
 	}
-
 	return all, nil
 }
 
@@ -29,7 +29,9 @@ func (c *CLOBClient) GetTickSize(tokenID string) (string, error) {
 	if tokenID == "" {
 		return "", ErrInvalidArgument("tokenID is required")
 	}
-	if tick, ok := c.tickSizeCache[tokenID]; ok {
+	tick, ok := c.tickSizeCache[tokenID]
+	c.metrics.ObserveCacheLookup(MetricsCacheTickSize, ok)
+	if ok {
 		return tick, nil
 	}
 
@@ -39,7 +41,7 @@ func (c *CLOBClient) GetTickSize(tokenID string) (string, error) {
 	if err := c.http.Do(context.Background(), http.MethodGet, EndpointGetTickSize, vals, nil, nil, &resp); err != nil {
 		return "", err
 	}
-	tick := fmt.Sprintf("%g", resp.MinimumTickSize)
+	tick = fmt.Sprintf("%g", resp.MinimumTickSize)
 	c.tickSizeCache[tokenID] = tick
 	return tick, nil
 }
@@ -49,7 +51,9 @@ func (c *CLOBClient) GetNegRisk(tokenID string) (bool, error) {
 	if tokenID == "" {
 		return false, ErrInvalidArgument("tokenID is required")
 	}
-	if v, ok := c.negRiskCache[tokenID]; ok {
+	v, ok := c.negRiskCache[tokenID]
+	c.metrics.ObserveCacheLookup(MetricsCacheNegRisk, ok)
+	if ok {
 		return v, nil
 	}
 
@@ -68,7 +72,9 @@ func (c *CLOBClient) GetFeeRateBps(tokenID string) (int, error) {
 	if tokenID == "" {
 		return 0, ErrInvalidArgument("tokenID is required")
 	}
-	if v, ok := c.feeRateCache[tokenID]; ok {
+	v, ok := c.feeRateCache[tokenID]
+	c.metrics.ObserveCacheLookup(MetricsCacheFeeRate, ok)
+	if ok {
 		return v, nil
 	}
 
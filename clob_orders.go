@@ -6,7 +6,9 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	order_utils_model "github.com/polymarket/go-order-utils/pkg/model"
 )
@@ -58,6 +60,18 @@ type OrderResponse struct {
 	Status      string   `json:"status,omitempty"`
 }
 
+// Err 在订单未成功时返回 *OrderError（可配合 errors.Is/errors.As），成功时返回 nil。
+func (r *OrderResponse) Err() error {
+	if r == nil || (r.Success && r.ErrorMsg == "") {
+		return nil
+	}
+	msg := r.ErrorMsg
+	if msg == "" {
+		msg = "order was not accepted"
+	}
+	return newOrderError(r.OrderID, r.Status, msg)
+}
+
 // OrderArgs 包含限价订单参数。
 type OrderArgs struct {
 	TokenID     string `json:"token_id"`
@@ -106,6 +120,7 @@ func (c *CLOBClient) CreateOrder(args *OrderArgs) (*order_utils_model.SignedOrde
 }
 
 // PostOrder submits a signed order（提交单个订单，POST /order）。
+// 订单未被接受（success=false 或带 errorMsg）时同时返回响应与 *OrderError。
 func (c *CLOBClient) PostOrder(ctx context.Context, signedOrder *order_utils_model.SignedOrder, orderType OrderType) (*OrderResponse, error) {
 	return c.PostOrderWithOptions(ctx, signedOrder, orderType, PostOrderOptions{})
 }
@@ -118,7 +133,7 @@ type PostOrderOptions struct {
 	PostOnly bool
 }
 
-// PostOrderWithOptions 提交带有额外选项的已签名订单。
+// PostOrderWithOptions 提交带有额外选项的已签名订单；错误语义同 PostOrder。
 func (c *CLOBClient) PostOrderWithOptions(ctx context.Context, signedOrder *order_utils_model.SignedOrder, orderType OrderType, opts PostOrderOptions) (*OrderResponse, error) {
 	if signedOrder == nil {
 		return nil, ErrInvalidArgument("signedOrder is required")
@@ -156,10 +171,13 @@ func (c *CLOBClient) PostOrderWithOptions(ctx context.Context, signedOrder *orde
 	}
 
 	var resp OrderResponse
-	if err := c.http.DoRaw(ctx, http.MethodPost, path, nil, body, headers, &resp); err != nil {
+	start := time.Now()
+	err = c.http.DoRaw(ctx, http.MethodPost, path, nil, body, headers, &resp)
+	c.metrics.ObserveOrderSubmit(path, time.Since(start), err == nil && resp.Success)
+	if err != nil {
 		return nil, err
 	}
-	return &resp, nil
+	return &resp, resp.Err()
 }
 
 // PostOrdersArgs 批量下单参数（与 Node SDK PostOrdersArgs 对齐）。
@@ -212,6 +230,8 @@ func (c *CLOBClient) PostOrdersSigned(ctx context.Context, args []PostOrdersArgs
 }
 
 // PostOrders 提交多个订单。
+// 部分订单未被接受时仍返回全部响应，错误为各订单 *OrderError 的合并（按批次下标标注），
+// 可用 errors.Is 匹配哨兵错误，或逐个调用 OrderResponse.Err()。
 func (c *CLOBClient) PostOrders(ctx context.Context, orders []*PostOrder) ([]*OrderResponse, error) {
 	if len(orders) == 0 {
 		return nil, ErrInvalidArgument("orders is required")
@@ -237,10 +257,19 @@ func (c *CLOBClient) PostOrders(ctx context.Context, orders []*PostOrder) ([]*Or
 	}
 
 	var resp []*OrderResponse
-	if err := c.http.DoRaw(ctx, http.MethodPost, path, nil, body, headers, &resp); err != nil {
+	start := time.Now()
+	err = c.http.DoRaw(ctx, http.MethodPost, path, nil, body, headers, &resp)
+	c.metrics.ObserveOrderSubmit(path, time.Since(start), err == nil)
+	if err != nil {
 		return nil, err
 	}
-	return resp, nil
+	var errs []error
+	for i, r := range resp {
+		if err := r.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("order %d: %w", i, err))
+		}
+	}
+	return resp, errors.Join(errs...)
 }
 
 // CancelOrders 取消多个订单。
@@ -270,6 +299,18 @@ type CancelOrdersResponse struct {
 	NotCanceled map[string]string `json:"not_canceled"`
 }
 
+// Errors 将 not_canceled 转换为按订单 ID 索引的 *OrderError；全部撤单成功时返回 nil。
+func (r *CancelOrdersResponse) Errors() map[string]error {
+	if r == nil || len(r.NotCanceled) == 0 {
+		return nil
+	}
+	out := make(map[string]error, len(r.NotCanceled))
+	for id, msg := range r.NotCanceled {
+		out[id] = newOrderError(id, "", msg)
+	}
+	return out
+}
+
 // CancelOrder 取消单个订单。
 func (c *CLOBClient) CancelOrder(ctx context.Context, orderID string) (*CancelOrdersResponse, error) {
 	if orderID == "" {
@@ -0,0 +1,240 @@
+// clob_pagination.go 模块
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+)
+
+// CursorFetcher 按游标获取一页数据。
+type CursorFetcher[T any] func(ctx context.Context, cursor string) (*PaginatedResponse[T], error)
+
+// PageCheckpoint 是游标分页的断点：从 Cursor 对应的页开始，跳过前 Skip 条。
+// 保存 CursorPaginator.Checkpoint() 并在下次通过 CursorOptions.Start 传入即可续传。
+type PageCheckpoint struct {
+	Cursor string `json:"cursor"`
+	Skip   int    `json:"skip,omitempty"`
+}
+
+// CursorOptions 控制游标分页。
+type CursorOptions struct {
+	// Start 起始断点；Cursor 为空时从 InitialCursor 开始。
+	Start PageCheckpoint
+	// MaxItems 最多产出的条数（分页器生命周期内累计）；0 不限。
+	MaxItems int
+}
+
+// CursorPaginator 是 CLOB 游标分页（next_cursor，InitialCursor 起、EndCursor 止）的通用实现，
+// 提供迭代器与一次性收集两种形式，并记录可续传的断点。非并发安全。
+type CursorPaginator[T any] struct {
+	fetch    CursorFetcher[T]
+	maxItems int
+	next     PageCheckpoint
+	yielded  int
+}
+
+// NewCursorPaginator 创建游标分页器。
+func NewCursorPaginator[T any](fetch CursorFetcher[T], opts CursorOptions) *CursorPaginator[T] {
+	next := opts.Start
+	if next.Cursor == "" {
+		next.Cursor = InitialCursor
+	}
+	return &CursorPaginator[T]{fetch: fetch, maxItems: opts.MaxItems, next: next}
+}
+
+// All 返回迭代器：逐页拉取并产出，直到 EndCursor、达到 MaxItems 或调用方停止迭代。
+// 出错或 ctx 取消时产出一次错误后结束，断点停留在出错的页，可再次调用 All 重试。
+// 服务端返回的 next_cursor 未前进（与已请求过的游标相同）时产出错误，避免无限循环。
+func (p *CursorPaginator[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		seen := make(map[string]bool)
+		for !p.Done() && !p.limited() {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			cursor := p.next.Cursor
+			seen[cursor] = true
+			page, err := p.fetch(ctx, cursor)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for i := min(p.next.Skip, len(page.Data)); i < len(page.Data); i++ {
+				if p.limited() {
+					return
+				}
+				p.next.Skip = i + 1
+				p.yielded++
+				if !yield(page.Data[i], nil) {
+					return
+				}
+			}
+			next := page.NextCursor
+			if next == "" {
+				next = EndCursor
+			}
+			if seen[next] {
+				yield(zero, fmt.Errorf("cursor pagination: next_cursor %q did not advance", next))
+				return
+			}
+			p.next = PageCheckpoint{Cursor: next}
+		}
+	}
+}
+
+// Collect 拉取剩余的全部数据；出错时返回已收集的部分与该错误。
+func (p *CursorPaginator[T]) Collect(ctx context.Context) ([]T, error) {
+	return Collect(p.All(ctx))
+}
+
+// Checkpoint 返回下一条数据的断点。
+func (p *CursorPaginator[T]) Checkpoint() PageCheckpoint {
+	return p.next
+}
+
+// Done 表示已到达最后一页。
+func (p *CursorPaginator[T]) Done() bool {
+	return p.next.Cursor == EndCursor
+}
+
+func (p *CursorPaginator[T]) limited() bool {
+	return p.maxItems > 0 && p.yielded >= p.maxItems
+}
+
+// ActiveOrdersPaginator 分页获取活跃订单（GET /data/orders）。
+func (c *CLOBClient) ActiveOrdersPaginator(req *GetActiveOrdersRequest, opts CursorOptions) *CursorPaginator[*OpenOrder] {
+	return NewCursorPaginator(func(ctx context.Context, cursor string) (*PaginatedResponse[*OpenOrder], error) {
+		resp, err := c.GetActiveOrdersPage(ctx, req, cursor)
+		if err != nil {
+			return nil, err
+		}
+		return &PaginatedResponse[*OpenOrder]{Limit: resp.Limit, Count: resp.Count, NextCursor: resp.NextCursor, Data: resp.Data}, nil
+	}, opts)
+}
+
+// TradesPaginator 分页获取交易（GET /data/trades）。
+func (c *CLOBClient) TradesPaginator(req *GetTradesRequest, opts CursorOptions) *CursorPaginator[*Trade] {
+	return NewCursorPaginator(func(ctx context.Context, cursor string) (*PaginatedResponse[*Trade], error) {
+		resp, err := c.GetTradesPage(ctx, req, cursor)
+		if err != nil {
+			return nil, err
+		}
+		return &PaginatedResponse[*Trade]{Limit: resp.Limit, Count: resp.Count, NextCursor: resp.NextCursor, Data: resp.Data}, nil
+	}, opts)
+}
+
+// BuilderTradesPaginator 分页获取 builder 成交（builder auth）。
+func (c *CLOBClient) BuilderTradesPaginator(params *TradeParams, opts CursorOptions) *CursorPaginator[BuilderTrade] {
+	return NewCursorPaginator(func(ctx context.Context, cursor string) (*PaginatedResponse[BuilderTrade], error) {
+		resp, err := c.GetBuilderTradesPage(ctx, params, cursor)
+		if err != nil {
+			return nil, err
+		}
+		return &PaginatedResponse[BuilderTrade]{Limit: resp.Limit, Count: resp.Count, NextCursor: resp.NextCursor, Data: resp.Data}, nil
+	}, opts)
+}
+
+// EarningsForUserForDayPaginator 分页获取用户某一天的收益明细（GET /rewards/user，L2 认证）。
+func (c *CLOBClient) EarningsForUserForDayPaginator(date string, opts CursorOptions) *CursorPaginator[UserEarning] {
+	return NewCursorPaginator(func(ctx context.Context, cursor string) (*PaginatedResponse[UserEarning], error) {
+		return c.getEarningsForUserForDayPage(ctx, date, cursor)
+	}, opts)
+}
+
+// UserEarningsAndMarketsConfigPaginator 分页获取用户某天在各市场的收益与奖励配置（GET /rewards/user/markets，L2 认证）。
+func (c *CLOBClient) UserEarningsAndMarketsConfigPaginator(date string, orderBy string, position string, noCompetition bool, opts CursorOptions) *CursorPaginator[UserRewardsEarning] {
+	return NewCursorPaginator(func(ctx context.Context, cursor string) (*PaginatedResponse[UserRewardsEarning], error) {
+		return c.getUserEarningsAndMarketsConfigPage(ctx, date, orderBy, position, noCompetition, cursor)
+	}, opts)
+}
+
+// CurrentRewardsPaginator 分页获取当前所有奖励市场（GET /rewards/markets/current）。
+func (c *CLOBClient) CurrentRewardsPaginator(opts CursorOptions) *CursorPaginator[MarketReward] {
+	return NewCursorPaginator(func(ctx context.Context, cursor string) (*PaginatedResponse[MarketReward], error) {
+		return c.getMarketRewardsPage(ctx, EndpointGetRewardsMarketsCurrent, cursor)
+	}, opts)
+}
+
+// RawRewardsForMarketPaginator 分页获取某个市场的奖励配置（GET /rewards/markets/{condition_id}）。
+func (c *CLOBClient) RawRewardsForMarketPaginator(conditionID string, opts CursorOptions) *CursorPaginator[MarketReward] {
+	path := EndpointGetRewardsMarketsPrefix + url.PathEscape(conditionID)
+	return NewCursorPaginator(func(ctx context.Context, cursor string) (*PaginatedResponse[MarketReward], error) {
+		return c.getMarketRewardsPage(ctx, path, cursor)
+	}, opts)
+}
+
+// MarketsPaginator 分页获取 CLOB markets（元素为原始 JSON）。
+func (c *CLOBClient) MarketsPaginator(opts CursorOptions) *CursorPaginator[json.RawMessage] {
+	return c.marketListPaginator(EndpointGetMarkets, opts)
+}
+
+// SimplifiedMarketsPaginator 分页获取 simplified markets。
+func (c *CLOBClient) SimplifiedMarketsPaginator(opts CursorOptions) *CursorPaginator[json.RawMessage] {
+	return c.marketListPaginator(EndpointGetSimplifiedMarkets, opts)
+}
+
+// SamplingMarketsPaginator 分页获取 sampling markets。
+func (c *CLOBClient) SamplingMarketsPaginator(opts CursorOptions) *CursorPaginator[json.RawMessage] {
+	return c.marketListPaginator(EndpointGetSamplingMarkets, opts)
+}
+
+// SamplingSimplifiedMarketsPaginator 分页获取 sampling simplified markets。
+func (c *CLOBClient) SamplingSimplifiedMarketsPaginator(opts CursorOptions) *CursorPaginator[json.RawMessage] {
+	return c.marketListPaginator(EndpointGetSamplingSimplifiedMarkets, opts)
+}
+
+func (c *CLOBClient) marketListPaginator(path string, opts CursorOptions) *CursorPaginator[json.RawMessage] {
+	return NewCursorPaginator(func(ctx context.Context, cursor string) (*PaginatedResponse[json.RawMessage], error) {
+		resp, err := c.getMarketList(ctx, path, cursor)
+		if err != nil {
+			return nil, err
+		}
+		return &PaginatedResponse[json.RawMessage]{Limit: resp.Limit, Count: resp.Count, NextCursor: resp.NextCursor, Data: resp.Data}, nil
+	}, opts)
+}
+
+// RfqRequestsPaginator 分页获取 RFQ requests；游标通过 params.Offset 传递（首页沿用 params.Offset，默认为空）。
+func (c *CLOBClient) RfqRequestsPaginator(params *GetRfqRequestsParams, opts CursorOptions) *CursorPaginator[RfqRequest] {
+	return NewCursorPaginator(func(ctx context.Context, cursor string) (*PaginatedResponse[RfqRequest], error) {
+		var page GetRfqRequestsParams
+		if params != nil {
+			page = *params
+		}
+		page.Offset = rfqOffset(page.Offset, cursor)
+		return c.GetRfqRequests(ctx, &page)
+	}, opts)
+}
+
+// RfqRequesterQuotesPaginator 分页获取 requester 视角的 quotes。
+func (c *CLOBClient) RfqRequesterQuotesPaginator(params *GetRfqQuotesParams, opts CursorOptions) *CursorPaginator[RfqQuote] {
+	return c.rfqQuotesPaginator(EndpointGetRfqRequesterQuotes, params, opts)
+}
+
+// RfqQuoterQuotesPaginator 分页获取 quoter 视角的 quotes。
+func (c *CLOBClient) RfqQuoterQuotesPaginator(params *GetRfqQuotesParams, opts CursorOptions) *CursorPaginator[RfqQuote] {
+	return c.rfqQuotesPaginator(EndpointGetRfqQuoterQuotes, params, opts)
+}
+
+func (c *CLOBClient) rfqQuotesPaginator(path string, params *GetRfqQuotesParams, opts CursorOptions) *CursorPaginator[RfqQuote] {
+	return NewCursorPaginator(func(ctx context.Context, cursor string) (*PaginatedResponse[RfqQuote], error) {
+		var page GetRfqQuotesParams
+		if params != nil {
+			page = *params
+		}
+		page.Offset = rfqOffset(page.Offset, cursor)
+		return c.getRfqQuotes(ctx, path, &page)
+	}, opts)
+}
+
+// rfqOffset 把分页游标映射为 RFQ 的 offset 参数：InitialCursor 表示首页，使用调用方给出的起始 offset。
+func rfqOffset(start, cursor string) string {
+	if cursor == InitialCursor {
+		return start
+	}
+	return cursor
+}
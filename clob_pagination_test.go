@@ -0,0 +1,183 @@
+// clob_pagination_test.go 模块
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// cursorPages 按游标返回预设的页，并记录请求过的游标。
+type cursorPages struct {
+	pages map[string]*PaginatedResponse[int]
+	calls []string
+	fail  map[string]error
+}
+
+func (c *cursorPages) fetch(_ context.Context, cursor string) (*PaginatedResponse[int], error) {
+	c.calls = append(c.calls, cursor)
+	if err := c.fail[cursor]; err != nil {
+		delete(c.fail, cursor)
+		return nil, err
+	}
+	if p, ok := c.pages[cursor]; ok {
+		return p, nil
+	}
+	return nil, errors.New("unexpected cursor " + cursor)
+}
+
+func TestCursorPaginatorTermination(t *testing.T) {
+	tests := []struct {
+		name      string
+		last      string
+		maxItems  int
+		wantItems []int
+		wantCalls []string
+		wantDone  bool
+	}{
+		{"end cursor", EndCursor, 0, []int{1, 2, 3, 4, 5}, []string{InitialCursor, "p2"}, true},
+		{"empty cursor", "", 0, []int{1, 2, 3, 4, 5}, []string{InitialCursor, "p2"}, true},
+		{"max items mid page", EndCursor, 4, []int{1, 2, 3, 4}, []string{InitialCursor, "p2"}, false},
+		{"max items at page end", EndCursor, 3, []int{1, 2, 3}, []string{InitialCursor}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &cursorPages{pages: map[string]*PaginatedResponse[int]{
+				InitialCursor: {Data: []int{1, 2, 3}, NextCursor: "p2"},
+				"p2":          {Data: []int{4, 5}, NextCursor: tt.last},
+			}}
+			p := NewCursorPaginator(src.fetch, CursorOptions{MaxItems: tt.maxItems})
+			got, err := p.Collect(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.wantItems) || !slices.Equal(src.calls, tt.wantCalls) {
+				t.Fatalf("items = %v calls = %v, want %v %v", got, src.calls, tt.wantItems, tt.wantCalls)
+			}
+			if p.Done() != tt.wantDone {
+				t.Fatalf("Done() = %v, want %v", p.Done(), tt.wantDone)
+			}
+			// 结束后再次迭代不应再请求。
+			if more, _ := p.Collect(context.Background()); len(more) != 0 || len(src.calls) != len(tt.wantCalls) {
+				t.Fatalf("second Collect = %v calls = %v", more, src.calls)
+			}
+		})
+	}
+}
+
+func TestCursorPaginatorResume(t *testing.T) {
+	boom := errors.New("boom")
+	src := &cursorPages{
+		pages: map[string]*PaginatedResponse[int]{
+			InitialCursor: {Data: []int{1, 2, 3}, NextCursor: "p2"},
+			"p2":          {Data: []int{4, 5}, NextCursor: EndCursor},
+		},
+		fail: map[string]error{"p2": boom},
+	}
+	p := NewCursorPaginator(src.fetch, CursorOptions{})
+	got, err := p.Collect(context.Background())
+	if !errors.Is(err, boom) || !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("got %v, %v", got, err)
+	}
+	if cp := p.Checkpoint(); cp != (PageCheckpoint{Cursor: "p2"}) {
+		t.Fatalf("checkpoint = %+v", cp)
+	}
+
+	// 通过断点在新的分页器上续传，跳过已产出的条目。
+	p2 := NewCursorPaginator(src.fetch, CursorOptions{Start: PageCheckpoint{Cursor: InitialCursor, Skip: 2}})
+	rest, err := p2.Collect(context.Background())
+	if err != nil || !slices.Equal(rest, []int{3, 4, 5}) {
+		t.Fatalf("resumed = %v, %v", rest, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p3 := NewCursorPaginator(src.fetch, CursorOptions{})
+	if _, err := p3.Collect(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled ctx err = %v", err)
+	}
+}
+
+func TestCursorPaginatorStalledCursor(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages map[string]*PaginatedResponse[int]
+		want  []int
+	}{
+		{"same cursor", map[string]*PaginatedResponse[int]{
+			InitialCursor: {Data: []int{1}, NextCursor: "p2"},
+			"p2":          {Data: []int{2}, NextCursor: "p2"},
+		}, []int{1, 2}},
+		{"back to initial", map[string]*PaginatedResponse[int]{
+			InitialCursor: {Data: []int{1}, NextCursor: InitialCursor},
+		}, []int{1}},
+		{"cycle", map[string]*PaginatedResponse[int]{
+			InitialCursor: {Data: []int{1}, NextCursor: "p2"},
+			"p2":          {Data: []int{2}, NextCursor: InitialCursor},
+		}, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &cursorPages{pages: tt.pages}
+			p := NewCursorPaginator(src.fetch, CursorOptions{})
+			got, err := p.Collect(context.Background())
+			if err == nil || !strings.Contains(err.Error(), "did not advance") {
+				t.Fatalf("err = %v, want stalled cursor error", err)
+			}
+			if !slices.Equal(got, tt.want) || len(src.calls) != len(tt.want) {
+				t.Fatalf("items = %v calls = %v, want %v", got, src.calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestRfqPaginatorOffsets(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		offsets []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+		mu.Lock()
+		offsets = append(offsets, r.URL.Path+"?"+offset)
+		mu.Unlock()
+		next := EndCursor
+		if offset == "" || offset == "10" {
+			next = "p2"
+		}
+		fmt.Fprintf(w, `{"data":[{}],"next_cursor":%q}`, next)
+	}))
+	defer srv.Close()
+	sdk, err := New(Config{
+		BaseURL:     srv.URL,
+		CLOBBaseURL: srv.URL,
+		Address:     "0x0000000000000000000000000000000000000001",
+		APIKey:      "key",
+		APISecret:   "c2VjcmV0",
+		Passphrase:  "pass",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err := sdk.CLOB.RfqRequestsPaginator(nil, CursorOptions{}).Collect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sdk.CLOB.RfqQuoterQuotesPaginator(&GetRfqQuotesParams{Offset: "10"}, CursorOptions{}).Collect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// 首页不应把 InitialCursor（"MA=="）作为 offset 发送；调用方给出的起始 offset 保留。
+	want := []string{
+		EndpointGetRfqRequests + "?", EndpointGetRfqRequests + "?p2",
+		EndpointGetRfqQuoterQuotes + "?10", EndpointGetRfqQuoterQuotes + "?p2",
+	}
+	if !slices.Equal(offsets, want) {
+		t.Fatalf("requests = %v, want %v", offsets, want)
+	}
+}
@@ -13,29 +13,30 @@ func (c *CLOBClient) GetEarningsForUserForDay(ctx context.Context, date string)
 	if date == "" {
 		return nil, ErrInvalidArgument("date is required")
 	}
+	all, err := c.EarningsForUserForDayPaginator(date, CursorOptions{}).Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+func (c *CLOBClient) getEarningsForUserForDayPage(ctx context.Context, date string, cursor string) (*PaginatedResponse[UserEarning], error) {
 	path := EndpointGetEarningsForUserForDay
+	vals := url.Values{}
+	vals.Set("date", date)
+	vals.Set("signature_type", strconv.Itoa(c.sigType))
+	vals.Set("next_cursor", cursor)
+
+	headers, err := c.l2Headers(http.MethodGet, path, "")
+	if err != nil {
+		return nil, err
+	}
 
-	nextCursor := InitialCursor
-	var all []UserEarning
-	for nextCursor != EndCursor {
-		vals := url.Values{}
-		vals.Set("date", date)
-		vals.Set("signature_type", strconv.Itoa(c.sigType))
-		vals.Set("next_cursor", nextCursor)
-
-		headers, err := c.l2Headers(http.MethodGet, path, "")
-		if err != nil {
-			return nil, err
-		}
-
-		var page PaginatedResponse[UserEarning]
-		if err := c.http.Do(ctx, http.MethodGet, path, vals, nil, headers, &page); err != nil {
-			return nil, err
-		}
-		all = append(all, page.Data...)
-		nextCursor = page.NextCursor
+	var page PaginatedResponse[UserEarning]
+	if err := c.http.Do(ctx, http.MethodGet, path, vals, nil, headers, &page); err != nil {
+		return nil, err
 	}
-	return all, nil
+	return &page, nil
 }
 
 // GetTotalEarningsForUserForDay 获取用户某一天的总收益（GET /rewards/user/total，L2 认证）。
@@ -65,38 +66,39 @@ func (c *CLOBClient) GetUserEarningsAndMarketsConfig(ctx context.Context, date s
 	if date == "" {
 		return nil, ErrInvalidArgument("date is required")
 	}
+	all, err := c.UserEarningsAndMarketsConfigPaginator(date, orderBy, position, noCompetition, CursorOptions{}).Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+func (c *CLOBClient) getUserEarningsAndMarketsConfigPage(ctx context.Context, date string, orderBy string, position string, noCompetition bool, cursor string) (*PaginatedResponse[UserRewardsEarning], error) {
 	path := EndpointGetRewardsEarningsPercentages
+	vals := url.Values{}
+	vals.Set("date", date)
+	vals.Set("signature_type", strconv.Itoa(c.sigType))
+	vals.Set("next_cursor", cursor)
+	if orderBy != "" {
+		vals.Set("order_by", orderBy)
+	}
+	if position != "" {
+		vals.Set("position", position)
+	}
+	if noCompetition {
+		vals.Set("no_competition", "true")
+	}
+
+	headers, err := c.l2Headers(http.MethodGet, path, "")
+	if err != nil {
+		return nil, err
+	}
 
-	nextCursor := InitialCursor
-	var all []UserRewardsEarning
-	for nextCursor != EndCursor {
-		vals := url.Values{}
-		vals.Set("date", date)
-		vals.Set("signature_type", strconv.Itoa(c.sigType))
-		vals.Set("next_cursor", nextCursor)
-		if orderBy != "" {
-			vals.Set("order_by", orderBy)
-		}
-		if position != "" {
-			vals.Set("position", position)
-		}
-		if noCompetition {
-			vals.Set("no_competition", "true")
-		}
-
-		headers, err := c.l2Headers(http.MethodGet, path, "")
-		if err != nil {
-			return nil, err
-		}
-
-		var page PaginatedResponse[UserRewardsEarning]
-		if err := c.http.Do(ctx, http.MethodGet, path, vals, nil, headers, &page); err != nil {
-			return nil, err
-		}
-		all = append(all, page.Data...)
-		nextCursor = page.NextCursor
+	var page PaginatedResponse[UserRewardsEarning]
+	if err := c.http.Do(ctx, http.MethodGet, path, vals, nil, headers, &page); err != nil {
+		return nil, err
 	}
-	return all, nil
+	return &page, nil
 }
 
 // GetRewardPercentages 获取用户各市场奖励占比（GET /rewards/user/percentages，L2 认证）。
@@ -119,20 +121,9 @@ func (c *CLOBClient) GetRewardPercentages(ctx context.Context) (RewardsPercentag
 
 // GetCurrentRewards 获取当前所有奖励市场（GET /rewards/markets/current，公开接口，自动分页）。
 func (c *CLOBClient) GetCurrentRewards(ctx context.Context) ([]MarketReward, error) {
-	path := EndpointGetRewardsMarketsCurrent
-	nextCursor := InitialCursor
-	var all []MarketReward
-
-	for nextCursor != EndCursor {
-		vals := url.Values{}
-		vals.Set("next_cursor", nextCursor)
-
-		var page PaginatedResponse[MarketReward]
-		if err := c.http.Do(ctx, http.MethodGet, path, vals, nil, nil, &page); err != nil {
-			return nil, err
-		}
-		all = append(all, page.Data...)
-		nextCursor = page.NextCursor
+	all, err := c.CurrentRewardsPaginator(CursorOptions{}).Collect(ctx)
+	if err != nil {
+		return nil, err
 	}
 	return all, nil
 }
@@ -142,20 +133,20 @@ func (c *CLOBClient) GetRawRewardsForMarket(ctx context.Context, conditionID str
 	if conditionID == "" {
 		return nil, ErrInvalidArgument("conditionID is required")
 	}
-	path := EndpointGetRewardsMarketsPrefix + url.PathEscape(conditionID)
-	nextCursor := InitialCursor
-	var all []MarketReward
+	all, err := c.RawRewardsForMarketPaginator(conditionID, CursorOptions{}).Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
 
-	for nextCursor != EndCursor {
-		vals := url.Values{}
-		vals.Set("next_cursor", nextCursor)
+func (c *CLOBClient) getMarketRewardsPage(ctx context.Context, path string, cursor string) (*PaginatedResponse[MarketReward], error) {
+	vals := url.Values{}
+	vals.Set("next_cursor", cursor)
 
-		var page PaginatedResponse[MarketReward]
-		if err := c.http.Do(ctx, http.MethodGet, path, vals, nil, nil, &page); err != nil {
-			return nil, err
-		}
-		all = append(all, page.Data...)
-		nextCursor = page.NextCursor
+	var page PaginatedResponse[MarketReward]
+	if err := c.http.Do(ctx, http.MethodGet, path, vals, nil, nil, &page); err != nil {
+		return nil, err
 	}
-	return all, nil
+	return &page, nil
 }
@@ -50,18 +50,10 @@ type GetTradesResponse struct {
 
 // GetTrades returns trades and auto-pages until end.
 func (c *CLOBClient) GetTrades(ctx context.Context, req *GetTradesRequest) ([]*Trade, error) {
-	nextCursor := InitialCursor
-	var all []*Trade
-
-	for nextCursor != EndCursor {
-		resp, err := c.GetTradesPage(ctx, req, nextCursor)
-		if err != nil {
-			return nil, err
-		}
-		all = append(all, resp.Data...)
-		nextCursor = resp.NextCursor
+	all, err := c.TradesPaginator(req, CursorOptions{}).Collect(ctx)
+	if err != nil {
+		return nil, err
 	}
-
 	return all, nil
 }
 
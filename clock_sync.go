@@ -0,0 +1,191 @@
+// clock_sync.go 模块
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultClockSyncSamples 每轮同步采样服务器时间的次数。
+	DefaultClockSyncSamples = 3
+	// GTDSecurityThreshold GTD 订单的服务端安全阈值：过期时间需至少晚于当前时间 1 分钟。
+	GTDSecurityThreshold = time.Minute
+)
+
+// Clock 提供当前时间；L1/L2/builder 签名与 GTD 过期时间计算均使用它。
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// SystemClock 返回使用本地时间的 Clock。
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// ClockSyncOptions 控制 ClockSync 的采样行为。
+type ClockSyncOptions struct {
+	// Interval 周期性刷新间隔；<=0 时只在调用 Sync 时同步。
+	Interval time.Duration
+	// Samples 每轮采样次数（取 RTT 最小的样本）；<=0 时使用 DefaultClockSyncSamples。
+	Samples int
+}
+
+// ClockSync 通过采样 CLOB `GetServerTime` 估计本地时钟与服务器的偏移，并做 RTT 补偿。
+// 它实现 Clock，可通过 CLOBClient.SetClock 注入签名路径。
+type ClockSync struct {
+	fetch func(ctx context.Context) (int64, error)
+	local func() time.Time
+	opts  ClockSyncOptions
+
+	mu       sync.RWMutex
+	offset   time.Duration
+	rtt      time.Duration
+	lastSync time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewClockSync 创建基于 CLOB 服务器时间的 ClockSync。
+func NewClockSync(clob *CLOBClient, opts ClockSyncOptions) *ClockSync {
+	if opts.Samples <= 0 {
+		opts.Samples = DefaultClockSyncSamples
+	}
+	return &ClockSync{
+		fetch: clob.GetServerTime,
+		local: time.Now,
+		opts:  opts,
+	}
+}
+
+// Now 返回经过偏移校正的当前时间。
+func (s *ClockSync) Now() time.Time {
+	s.mu.RLock()
+	offset := s.offset
+	s.mu.RUnlock()
+	return s.local().Add(offset)
+}
+
+// Offset 返回当前估计的偏移（服务器时间 - 本地时间）。
+func (s *ClockSync) Offset() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.offset
+}
+
+// RTT 返回最近一轮同步中选用样本的往返耗时。
+func (s *ClockSync) RTT() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rtt
+}
+
+// LastSync 返回最近一次成功同步的本地时间；从未同步时为零值。
+func (s *ClockSync) LastSync() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastSync
+}
+
+// Sync 立即采样服务器时间并更新偏移。
+// 服务器时间精度为秒，因此取区间中点，并用 RTT/2 补偿单程延迟；多次采样时取 RTT 最小的样本。
+func (s *ClockSync) Sync(ctx context.Context) error {
+	var (
+		best    time.Duration
+		bestRTT time.Duration = -1
+		lastErr error
+	)
+	for i := 0; i < s.opts.Samples; i++ {
+		t0 := s.local()
+		serverSec, err := s.fetch(ctx)
+		t1 := s.local()
+		if err != nil {
+			lastErr = err
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		rtt := t1.Sub(t0)
+		server := time.Unix(serverSec, 0).Add(500 * time.Millisecond)
+		offset := server.Sub(t0.Add(rtt / 2))
+		if bestRTT < 0 || rtt < bestRTT {
+			best, bestRTT = offset, rtt
+		}
+	}
+	if bestRTT < 0 {
+		if lastErr == nil {
+			lastErr = errors.New("clock sync: no samples")
+		}
+		return lastErr
+	}
+
+	s.mu.Lock()
+	s.offset = best
+	s.rtt = bestRTT
+	s.lastSync = s.local()
+	s.mu.Unlock()
+	return nil
+}
+
+// Start 立即同步一次，并在 Interval > 0 时启动后台周期刷新，直到 ctx 结束或调用 Stop。
+// 首次同步失败不会阻止后台刷新，错误会被返回给调用方。
+func (s *ClockSync) Start(ctx context.Context) error {
+	err := s.Sync(ctx)
+	if s.opts.Interval <= 0 || ctx.Err() != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.cancel != nil {
+		// 上一次启动的刷新仍在运行时直接返回；其 ctx 已结束时允许重新启动。
+		select {
+		case <-s.done:
+		default:
+			s.mu.Unlock()
+			return err
+		}
+	}
+	loopCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	s.cancel = cancel
+	s.done = done
+	s.mu.Unlock()
+
+	go s.loop(loopCtx, done)
+	return err
+}
+
+// Stop 停止后台刷新。
+func (s *ClockSync) Stop() {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+func (s *ClockSync) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = s.Sync(ctx)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+// clock_sync_test.go 模块
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock 可手动推进的本地时钟。
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+// serverSample 一次服务器时间采样：返回 server 秒数，本地时钟在请求期间前进 rtt。
+type serverSample struct {
+	server int64
+	rtt    time.Duration
+	err    error
+}
+
+func newTestClockSync(local *fakeClock, samples []serverSample) *ClockSync {
+	i := 0
+	return &ClockSync{
+		local: local.Now,
+		opts:  ClockSyncOptions{Samples: len(samples)},
+		fetch: func(ctx context.Context) (int64, error) {
+			s := samples[i]
+			i++
+			local.advance(s.rtt)
+			return s.server, s.err
+		},
+	}
+}
+
+func TestClockSyncOffset(t *testing.T) {
+	base := time.Unix(1_700_000_000, 0)
+	boom := errors.New("boom")
+	tests := []struct {
+		name       string
+		samples    []serverSample
+		wantOffset time.Duration
+		wantRTT    time.Duration
+	}{
+		// 本地 t0=base，RTT 200ms：中点为 base+100ms，服务器秒中点为 base+10.5s。
+		{"server ahead", []serverSample{{base.Unix() + 10, 200 * time.Millisecond, nil}}, 10400 * time.Millisecond, 200 * time.Millisecond},
+		{"server behind", []serverSample{{base.Unix() - 5, 0, nil}}, -4500 * time.Millisecond, 0},
+		// 第二个样本 RTT 最小，应被选用；其 t0 = base+1s。
+		{"min rtt wins", []serverSample{
+			{base.Unix() + 30, time.Second, nil},
+			{base.Unix() + 21, 100 * time.Millisecond, nil},
+			{base.Unix() + 40, 800 * time.Millisecond, nil},
+		}, 20450 * time.Millisecond, 100 * time.Millisecond},
+		{"failed samples skipped", []serverSample{
+			{0, 0, boom},
+			{base.Unix() + 2, 0, nil},
+		}, 2500 * time.Millisecond, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local := &fakeClock{now: base}
+			s := newTestClockSync(local, tt.samples)
+			if err := s.Sync(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if s.Offset() != tt.wantOffset || s.RTT() != tt.wantRTT {
+				t.Fatalf("offset = %v rtt = %v, want %v %v", s.Offset(), s.RTT(), tt.wantOffset, tt.wantRTT)
+			}
+			if got, want := s.Now(), local.Now().Add(tt.wantOffset); !got.Equal(want) {
+				t.Fatalf("Now() = %v, want %v", got, want)
+			}
+			if !s.LastSync().Equal(local.Now()) {
+				t.Fatalf("LastSync() = %v, want %v", s.LastSync(), local.Now())
+			}
+		})
+	}
+}
+
+func TestClockSyncErrorKeepsOffset(t *testing.T) {
+	base := time.Unix(1_700_000_000, 0)
+	boom := errors.New("boom")
+	local := &fakeClock{now: base}
+	s := newTestClockSync(local, []serverSample{{base.Unix() + 10, 0, nil}, {0, 0, boom}, {0, 0, boom}})
+	s.opts.Samples = 1
+	if err := s.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	s.opts.Samples = 2
+	if err := s.Sync(context.Background()); !errors.Is(err, boom) {
+		t.Fatalf("Sync err = %v, want boom", err)
+	}
+	if s.Offset() != 10500*time.Millisecond {
+		t.Fatalf("offset after failed sync = %v, want previous 10.5s", s.Offset())
+	}
+}
+
+func TestClockSyncStartStop(t *testing.T) {
+	synced := make(chan struct{}, 16)
+	s := &ClockSync{
+		local: time.Now,
+		opts:  ClockSyncOptions{Interval: 5 * time.Millisecond, Samples: 1},
+		fetch: func(context.Context) (int64, error) {
+			select {
+			case synced <- struct{}{}:
+			default:
+			}
+			return time.Now().Unix(), nil
+		},
+	}
+	waitSync := func() {
+		t.Helper()
+		select {
+		case <-synced:
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for periodic sync")
+		}
+	}
+	drain := func() {
+		for len(synced) > 0 {
+			<-synced
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := s.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	drain()
+	waitSync()
+	// Stop 应等待后台刷新退出，之后不再采样。
+	s.Stop()
+	drain()
+	time.Sleep(30 * time.Millisecond)
+	if len(synced) != 0 {
+		t.Fatal("sync after Stop")
+	}
+
+	// 停止后可重新启动；ctx 结束同样终止后台刷新。
+	if err := s.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	drain()
+	waitSync()
+	s.mu.RLock()
+	done := s.done
+	s.mu.RUnlock()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("refresh loop still running after ctx cancel")
+	}
+	s.Stop()
+}
+
+// fixedClock 总是返回同一时间。
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+func TestSigningUsesClock(t *testing.T) {
+	skewed := time.Now().Add(-time.Hour).Truncate(time.Second)
+	want := strconv.FormatInt(skewed.Unix(), 10)
+
+	c := NewCLOBClient(nil, Config{
+		PrivateKey: "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318",
+		APIKey:     "key",
+		APISecret:  "c2VjcmV0",
+		Passphrase: "pass",
+		ChainID:    DefaultChainID,
+
+		BuilderAPIKey:     "builder",
+		BuilderAPISecret:  "c2VjcmV0",
+		BuilderPassphrase: "pass",
+	})
+	b := c.builderAuth
+	c.SetClock(fixedClock(skewed))
+
+	l1, err := c.l1Headers(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l2, err := c.l2Headers("GET", "/data/orders", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bh, err := b.Headers("POST", "/order", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l1["POLY_TIMESTAMP"] != want || l2["POLY_TIMESTAMP"] != want || bh["POLY_BUILDER_TIMESTAMP"] != want {
+		t.Fatalf("timestamps l1=%s l2=%s builder=%s, want %s", l1["POLY_TIMESTAMP"], l2["POLY_TIMESTAMP"], bh["POLY_BUILDER_TIMESTAMP"], want)
+	}
+	if got := c.Now(); !got.Equal(skewed) {
+		t.Fatalf("Now() = %v, want %v", got, skewed)
+	}
+
+	// nil 恢复本地时间。
+	c.SetClock(nil)
+	l2, _ = c.l2Headers("GET", "/data/orders", "")
+	if l2["POLY_TIMESTAMP"] == want {
+		t.Fatal("SetClock(nil) kept the skewed clock")
+	}
+}
@@ -1,7 +1,13 @@
 // config.go 模块
 package polymarket
 
-import "time"
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+
+	"github.com/dcsunny/polymarket-sdk/internal/httpx"
+)
 
 const (
 	DefaultBaseURL      = "https://gamma-api.polymarket.com"
@@ -10,8 +16,11 @@ const (
 	DefaultWSSUserURL   = "wss://ws-subscriptions-clob.polymarket.com/ws/user"
 	DefaultRTDSURL      = "wss://ws-live-data.polymarket.com"
 	DefaultRelayerURL   = "https://relay-v2.polymarket.com/"
+	DefaultDataAPIURL   = "https://data-api.polymarket.com"
 	DefaultTimeout      = 30 * time.Second
 	DefaultChainID      = ChainIDPolygon
+
+	DefaultClockSyncInterval = 5 * time.Minute
 )
 
 const (
@@ -28,6 +37,7 @@ type Config struct {
 	WSSUserURL   string
 	RTDSURL      string
 	RelayerURL   string
+	DataAPIURL   string
 
 	Timeout   time.Duration
 	Proxy     string
@@ -35,6 +45,31 @@ type Config struct {
 	ChainID   int64
 	UserAgent string
 
+	// HTTPClient 自定义 HTTP 客户端（可选），Gamma / CLOB / Data / Relayer 共用；
+	// 设置后沿用其自身的 Timeout 与 Transport（Proxy、TLSConfig 不再生效）。
+	HTTPClient *http.Client
+	// HTTPTransport 替换 Gamma / CLOB / Data / Relayer 请求的底层 RoundTripper（可选），
+	// 例如企业代理、polymarkettest.Cassette 的录制/回放。
+	HTTPTransport http.RoundTripper
+	// TLSConfig 自定义 TLS（mTLS、私有 CA 等），作用于内置 HTTP Transport 与 websocket 连接。
+	TLSConfig *tls.Config
+
+	// WSDialer websocket 拨号设置（WSS 与 RTDS 共用）。
+	WSDialer WSDialerOptions
+	// WSSReconnect WSS 断线自动重连策略（默认启用，无限重试）。
+	WSSReconnect ReconnectPolicy
+	// RTDSReconnect RTDS 断线自动重连策略（默认启用，无限重试）。
+	RTDSReconnect ReconnectPolicy
+	// WSStaleTimeout WSS 与 RTDS 连接的静默超时：超过该时间未收到任何消息（包括 PONG）即断开并重连；
+	// 0 使用默认值（WSS 为 DefaultWSStaleTimeout，RTDS 为 DefaultRTDSStaleTimeout），负数关闭检测。
+	WSStaleTimeout time.Duration
+
+	// GammaHTTP / CLOBHTTP / RelayerHTTP / DataHTTP 按客户端覆盖 HTTP 设置（超时、连接数等）。
+	GammaHTTP   HTTPOptions
+	CLOBHTTP    HTTPOptions
+	RelayerHTTP HTTPOptions
+	DataHTTP    HTTPOptions
+
 	// Auth
 	Address    string
 	PrivateKey string
@@ -53,6 +88,66 @@ type Config struct {
 	// Wallet
 	RPCURL      string
 	BuilderAuth string
+
+	// Metrics 指标记录器（可选）；为空时不记录。
+	Metrics Metrics
+
+	// ClockSync 启用服务器时钟偏移校正（签名时间戳与 GTD 过期时间使用校正后的时间）。
+	ClockSync bool
+	// ClockSyncInterval 时钟偏移的刷新间隔；启用 ClockSync 且为 0 时使用 DefaultClockSyncInterval。
+	ClockSyncInterval time.Duration
+}
+
+// HTTPOptions 覆盖单个 HTTP 客户端的设置；零值字段沿用 Config 的全局设置。
+type HTTPOptions struct {
+	Client    *http.Client
+	Transport http.RoundTripper
+	Timeout   time.Duration
+
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+}
+
+// httpOptions 合并全局设置与单个客户端的覆盖设置。
+func (c Config) httpOptions(o HTTPOptions) httpx.Options {
+	opts := httpx.Options{
+		Timeout:             o.Timeout,
+		Proxy:               c.Proxy,
+		UserAgent:           c.UserAgent,
+		Debug:               c.Debug,
+		Client:              o.Client,
+		Transport:           o.Transport,
+		TLSConfig:           c.TLSConfig,
+		MaxIdleConns:        o.MaxIdleConns,
+		MaxIdleConnsPerHost: o.MaxIdleConnsPerHost,
+		MaxConnsPerHost:     o.MaxConnsPerHost,
+		IdleConnTimeout:     o.IdleConnTimeout,
+	}
+	if opts.Client == nil {
+		opts.Client = c.HTTPClient
+	}
+	if opts.Transport == nil {
+		opts.Transport = c.HTTPTransport
+	}
+	if opts.Timeout == 0 && opts.Client == nil {
+		opts.Timeout = c.Timeout
+	}
+	return opts
+}
+
+// wsTLSConfig 返回 websocket 连接使用的 TLS 配置：优先 TLSConfig，其次 HTTPClient 的 Transport。
+func (c Config) wsTLSConfig() *tls.Config {
+	if c.TLSConfig != nil {
+		return c.TLSConfig
+	}
+	if c.HTTPClient != nil {
+		if t, ok := c.HTTPClient.Transport.(*http.Transport); ok {
+			return t.TLSClientConfig
+		}
+	}
+	return nil
 }
 
 func (c Config) withDefaults() Config {
@@ -74,6 +169,9 @@ func (c Config) withDefaults() Config {
 	if c.RelayerURL == "" {
 		c.RelayerURL = DefaultRelayerURL
 	}
+	if c.DataAPIURL == "" {
+		c.DataAPIURL = DefaultDataAPIURL
+	}
 	if c.Timeout == 0 {
 		c.Timeout = DefaultTimeout
 	}
@@ -83,5 +181,11 @@ func (c Config) withDefaults() Config {
 	if c.SignatureType == 0 {
 		c.SignatureType = SignatureTypeEOA
 	}
+	if c.ClockSync && c.ClockSyncInterval == 0 {
+		c.ClockSyncInterval = DefaultClockSyncInterval
+	}
+	if c.Metrics == nil {
+		c.Metrics = NopMetrics()
+	}
 	return c
 }
@@ -0,0 +1,291 @@
+// crypto_reference.go 模块
+package polymarket
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultReferencePriceHistory 参考价格默认保留的历史窗口。
+	DefaultReferencePriceHistory = time.Hour
+	// DefaultReferencePriceMaxAge PriceAt 默认接受的最大观测间隔。
+	DefaultReferencePriceMaxAge = 10 * time.Second
+)
+
+// ReferencePriceOptions 配置 CryptoReferencePrices。
+type ReferencePriceOptions struct {
+	// Symbols 关注的资产（如 "btc"、"eth"，也接受 "btcusdt"、"btc/usd"）；为空时接收全部。
+	Symbols []string
+	// History 每个来源保留的历史窗口；0 使用 DefaultReferencePriceHistory。
+	History time.Duration
+	// MaxAge PriceAt 接受的最大间隔：时间点 t 与其之前最后一次观测相差超过 MaxAge 时视为无价格
+	// （例如数据流中断）；0 使用 DefaultReferencePriceMaxAge，负数不限制。
+	MaxAge time.Duration
+	// DivergenceThreshold Binance 与 Chainlink 价格的相对偏离阈值（如 0.005 表示 0.5%）；0 关闭告警。
+	DivergenceThreshold float64
+	// OnDivergence 偏离超过阈值时调用；同一资产在恢复到阈值以内之前只告警一次。
+	OnDivergence func(ReferenceDivergence)
+}
+
+// ReferencePrice 是某一来源在某一时刻的价格。
+type ReferencePrice struct {
+	Symbol    string
+	Source    CryptoPriceSource
+	Value     float64
+	Timestamp time.Time
+}
+
+// ReferenceDivergence 描述两个来源之间的价格偏离。
+type ReferenceDivergence struct {
+	Symbol    string
+	Binance   ReferencePrice
+	Chainlink ReferencePrice
+	// Diff 为 |Binance - Chainlink| / Chainlink。
+	Diff float64
+}
+
+// CryptoReferencePrices 基于 RTDS 维护 Binance 与 Chainlink 的参考价格：
+// 每个资产、每个来源的最新价格与滚动历史，按时间点查询价格（用于 up/down 市场的行权价与结算价），
+// 以及两个来源偏离时的告警。资产统一归一化为小写基础币种（"BTCUSDT"、"btc/usd" 均为 "btc"）。
+type CryptoReferencePrices struct {
+	rtds *RTDSClient
+	opts ReferencePriceOptions
+
+	mu       sync.RWMutex
+	series   map[string]map[CryptoPriceSource][]ReferencePrice
+	diverged map[string]bool
+	closed   bool
+	offs     []func()
+}
+
+// NewCryptoReferencePrices 创建参考价格服务；调用 Start 后开始订阅。
+func NewCryptoReferencePrices(rtds *RTDSClient, opts ReferencePriceOptions) *CryptoReferencePrices {
+	if opts.History <= 0 {
+		opts.History = DefaultReferencePriceHistory
+	}
+	if opts.MaxAge == 0 {
+		opts.MaxAge = DefaultReferencePriceMaxAge
+	}
+	return &CryptoReferencePrices{
+		rtds:     rtds,
+		opts:     opts,
+		series:   make(map[string]map[CryptoPriceSource][]ReferencePrice),
+		diverged: make(map[string]bool),
+	}
+}
+
+// Start 订阅 Binance 与 Chainlink 价格并注册处理器（RTDS 连接前调用时，订阅在 Connect 时发送）。
+// 已启动时直接返回；Stop 之后可再次调用 Start 恢复记录。
+func (s *CryptoReferencePrices) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.offs != nil {
+		return nil
+	}
+	var binance, chainlink []string
+	for _, sym := range s.opts.Symbols {
+		base := ReferenceSymbol(sym)
+		binance = append(binance, base+"usdt")
+		chainlink = append(chainlink, base+"/usd")
+	}
+	// 处理器通过 On 单独注册，以便 Stop 时移除；资产过滤由 Observe 完成。
+	if err := s.rtds.SubscribeCryptoPrices(CryptoPriceSourceBinance, binance, nil); err != nil {
+		return err
+	}
+	if err := s.rtds.SubscribeCryptoPrices(CryptoPriceSourceChainlink, chainlink, nil); err != nil {
+		return err
+	}
+	for _, source := range []CryptoPriceSource{CryptoPriceSourceBinance, CryptoPriceSourceChainlink} {
+		s.offs = append(s.offs, s.rtds.On(string(source), RTDSTypeUpdate, s.handler(source)))
+	}
+	s.closed = false
+	return nil
+}
+
+// Stop 移除 RTDS 处理器并停止记录新价格（RTDS 订阅保持不变，已记录的数据仍可查询）。
+func (s *CryptoReferencePrices) Stop() {
+	s.mu.Lock()
+	offs := s.offs
+	s.offs = nil
+	s.closed = true
+	s.mu.Unlock()
+	for _, off := range offs {
+		off()
+	}
+}
+
+func (s *CryptoReferencePrices) handler(source CryptoPriceSource) RTDSMessageHandler {
+	return func(msg *RTDSMessage) error {
+		var p RTDSCryptoPricePayload
+		if err := json.Unmarshal(msg.Payload, &p); err != nil {
+			return err
+		}
+		ts := p.Timestamp
+		if ts == 0 {
+			ts = msg.Timestamp
+		}
+		s.Observe(source, p.Symbol, p.Value, time.UnixMilli(ts))
+		return nil
+	}
+}
+
+// Observe 记录一次价格观测（通常由 RTDS 订阅调用；也可用于回放历史数据）。
+func (s *CryptoReferencePrices) Observe(source CryptoPriceSource, symbol string, value float64, ts time.Time) {
+	base := ReferenceSymbol(symbol)
+	if !s.wanted(base) {
+		return
+	}
+	p := ReferencePrice{Symbol: base, Source: source, Value: value, Timestamp: ts}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	bySource := s.series[base]
+	if bySource == nil {
+		bySource = make(map[CryptoPriceSource][]ReferencePrice)
+		s.series[base] = bySource
+	}
+	bySource[source] = insertPrice(bySource[source], p, s.opts.History)
+	alert, ok := s.checkDivergence(base)
+	s.mu.Unlock()
+
+	if ok && s.opts.OnDivergence != nil {
+		s.opts.OnDivergence(alert)
+	}
+}
+
+// Latest 返回资产在某一来源的最新价格。
+func (s *CryptoReferencePrices) Latest(symbol string, source CryptoPriceSource) (ReferencePrice, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	h := s.series[ReferenceSymbol(symbol)][source]
+	if len(h) == 0 {
+		return ReferencePrice{}, false
+	}
+	return h[len(h)-1], true
+}
+
+// PriceAt 返回时间点 t 时的价格，即时间戳不晚于 t 的最后一次观测。
+// t 早于历史窗口内的第一条观测，或该观测早于 t 超过 MaxAge 时返回 false；
+// 需要自行判断间隔时可用 History 获取原始观测。
+func (s *CryptoReferencePrices) PriceAt(symbol string, source CryptoPriceSource, t time.Time) (ReferencePrice, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	h := s.series[ReferenceSymbol(symbol)][source]
+	i := sort.Search(len(h), func(i int) bool { return h[i].Timestamp.After(t) })
+	if i == 0 {
+		return ReferencePrice{}, false
+	}
+	p := h[i-1]
+	if s.opts.MaxAge > 0 && t.Sub(p.Timestamp) > s.opts.MaxAge {
+		return ReferencePrice{}, false
+	}
+	return p, true
+}
+
+// History 返回资产在某一来源自 since 起的历史价格（按时间升序）。
+func (s *CryptoReferencePrices) History(symbol string, source CryptoPriceSource, since time.Time) []ReferencePrice {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	h := s.series[ReferenceSymbol(symbol)][source]
+	i := sort.Search(len(h), func(i int) bool { return !h[i].Timestamp.Before(since) })
+	return append([]ReferencePrice(nil), h[i:]...)
+}
+
+// Divergence 返回资产当前 Binance 与 Chainlink 最新价格的相对偏离；任一来源缺失时返回 false。
+func (s *CryptoReferencePrices) Divergence(symbol string) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	d, ok := s.divergence(ReferenceSymbol(symbol))
+	return d.Diff, ok
+}
+
+// Symbols 返回已有价格数据的资产（已排序）。
+func (s *CryptoReferencePrices) Symbols() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]string, 0, len(s.series))
+	for sym := range s.series {
+		out = append(out, sym)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (s *CryptoReferencePrices) wanted(base string) bool {
+	if len(s.opts.Symbols) == 0 {
+		return true
+	}
+	for _, sym := range s.opts.Symbols {
+		if ReferenceSymbol(sym) == base {
+			return true
+		}
+	}
+	return false
+}
+
+// divergence 调用方需持有 mu。
+func (s *CryptoReferencePrices) divergence(base string) (ReferenceDivergence, bool) {
+	b := s.series[base][CryptoPriceSourceBinance]
+	c := s.series[base][CryptoPriceSourceChainlink]
+	if len(b) == 0 || len(c) == 0 || c[len(c)-1].Value == 0 {
+		return ReferenceDivergence{}, false
+	}
+	d := ReferenceDivergence{Symbol: base, Binance: b[len(b)-1], Chainlink: c[len(c)-1]}
+	d.Diff = math.Abs(d.Binance.Value-d.Chainlink.Value) / d.Chainlink.Value
+	return d, true
+}
+
+// checkDivergence 判断是否需要告警并更新告警状态；调用方需持有 mu。
+func (s *CryptoReferencePrices) checkDivergence(base string) (ReferenceDivergence, bool) {
+	if s.opts.DivergenceThreshold <= 0 {
+		return ReferenceDivergence{}, false
+	}
+	d, ok := s.divergence(base)
+	if !ok {
+		return d, false
+	}
+	if d.Diff <= s.opts.DivergenceThreshold {
+		delete(s.diverged, base)
+		return d, false
+	}
+	if s.diverged[base] {
+		return d, false
+	}
+	s.diverged[base] = true
+	return d, true
+}
+
+// insertPrice 按时间戳有序插入并裁剪超出窗口（相对最新观测）的旧数据。
+// 裁剪只移动切片起点而不复制；被裁掉的前缀在下次 append 扩容时释放，每次观测均摊 O(1)。
+func insertPrice(h []ReferencePrice, p ReferencePrice, window time.Duration) []ReferencePrice {
+	i := sort.Search(len(h), func(i int) bool { return h[i].Timestamp.After(p.Timestamp) })
+	h = append(h, ReferencePrice{})
+	copy(h[i+1:], h[i:])
+	h[i] = p
+
+	cutoff := h[len(h)-1].Timestamp.Add(-window)
+	j := sort.Search(len(h), func(i int) bool { return !h[i].Timestamp.Before(cutoff) })
+	return h[j:]
+}
+
+// ReferenceSymbol 把不同来源的交易对归一化为小写基础币种："BTCUSDT"、"btc/usd" → "btc"。
+func ReferenceSymbol(symbol string) string {
+	s := strings.ToLower(strings.TrimSpace(symbol))
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		return s[:i]
+	}
+	for _, quote := range []string{"usdt", "usdc", "usd"} {
+		if len(s) > len(quote) && strings.HasSuffix(s, quote) {
+			return strings.TrimSuffix(s, quote)
+		}
+	}
+	return s
+}
@@ -0,0 +1,127 @@
+// crypto_reference_test.go 模块
+package polymarket_test
+
+import (
+	"testing"
+	"time"
+
+	pm "github.com/dcsunny/polymarket-sdk"
+	"github.com/dcsunny/polymarket-sdk/polymarkettest"
+)
+
+func waitLatest(t *testing.T, srv *polymarkettest.Server, ref *pm.CryptoReferencePrices, value float64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if p, ok := ref.Latest("btc", pm.CryptoPriceSourceBinance); ok && p.Value == value {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for btc price %v", value)
+		}
+		publishPrice(srv, "btcusdt", value)
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCryptoReferencePricesStopRemovesHandlers(t *testing.T) {
+	srv := polymarkettest.NewServer(polymarkettest.Options{})
+	defer srv.Close()
+	sdk, err := pm.New(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sdk.RTDS.Close()
+
+	ref := pm.NewCryptoReferencePrices(sdk.RTDS, pm.ReferencePriceOptions{Symbols: []string{"btc"}})
+	probe := &recorder{}
+	sdk.RTDS.On(string(pm.CryptoPriceSourceBinance), pm.RTDSTypeUpdate, probe.handler)
+	if err := ref.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sdk.RTDS.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	waitLatest(t, srv, ref, 1)
+
+	// 反复 Stop/Start 不应累积处理器。
+	for i := 0; i < 3; i++ {
+		ref.Stop()
+		if err := ref.Start(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ref.Start(); err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Now().Add(time.Minute).Truncate(time.Millisecond)
+	srv.PublishRTDS(string(pm.CryptoPriceSourceBinance), pm.RTDSTypeUpdate,
+		pm.RTDSCryptoPricePayload{Symbol: "btcusdt", Value: 2, Timestamp: ts.UnixMilli()})
+	waitLatest(t, srv, ref, 2)
+	if h := ref.History("btc", pm.CryptoPriceSourceBinance, ts); len(h) != 1 {
+		t.Fatalf("observed tick %d times, want 1: %+v", len(h), h)
+	}
+
+	ref.Stop()
+	publishPrice(srv, "btcusdt", 3)
+	syncRTDS(t, srv, probe, "btcusdt", -1)
+	if p, _ := ref.Latest("btc", pm.CryptoPriceSourceBinance); p.Value != 2 {
+		t.Fatalf("latest after Stop = %v, want 2", p.Value)
+	}
+}
+
+func TestCryptoReferencePriceAtMaxAge(t *testing.T) {
+	t0 := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name   string
+		maxAge time.Duration
+		at     time.Duration
+		want   bool
+	}{
+		{"within default", 0, 5 * time.Second, true},
+		{"beyond default", 0, pm.DefaultReferencePriceMaxAge + time.Second, false},
+		{"custom max age", time.Minute, 30 * time.Second, true},
+		{"beyond custom", time.Minute, 2 * time.Minute, false},
+		{"unlimited", -1, 50 * time.Minute, true},
+		{"before first", -1, -time.Second, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref := pm.NewCryptoReferencePrices(nil, pm.ReferencePriceOptions{MaxAge: tt.maxAge})
+			ref.Observe(pm.CryptoPriceSourceChainlink, "btc/usd", 100, t0)
+			p, ok := ref.PriceAt("btc", pm.CryptoPriceSourceChainlink, t0.Add(tt.at))
+			if ok != tt.want || (ok && p.Value != 100) {
+				t.Fatalf("PriceAt = %+v, %v; want ok=%v", p, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestCryptoReferencePriceHistoryTrim(t *testing.T) {
+	const window = time.Minute
+	ref := pm.NewCryptoReferencePrices(nil, pm.ReferencePriceOptions{History: window})
+	t0 := time.Unix(1_700_000_000, 0)
+	n := 0
+	observe := func() {
+		ref.Observe(pm.CryptoPriceSourceBinance, "btcusdt", float64(n), t0.Add(time.Duration(n)*time.Second))
+		n++
+	}
+	for range 600 {
+		observe()
+	}
+	// 窗口已满后每次观测都会裁剪一条旧数据，裁剪不应每次都重新分配。
+	if allocs := testing.AllocsPerRun(1000, observe); allocs > 0.1 {
+		t.Fatalf("Observe allocs = %v per call, want amortized ~0", allocs)
+	}
+
+	latest := t0.Add(time.Duration(n-1) * time.Second)
+	h := ref.History("btc", pm.CryptoPriceSourceBinance, time.Time{})
+	if len(h) != int(window/time.Second)+1 || !h[0].Timestamp.Equal(latest.Add(-window)) || !h[len(h)-1].Timestamp.Equal(latest) {
+		t.Fatalf("history = %d items [%v, %v]", len(h), h[0].Timestamp, h[len(h)-1].Timestamp)
+	}
+	// 乱序观测仍按时间戳插入。
+	ref.Observe(pm.CryptoPriceSourceBinance, "btcusdt", -1, latest.Add(-1500*time.Millisecond))
+	if p, ok := ref.PriceAt("btc", pm.CryptoPriceSourceBinance, latest.Add(-time.Second-time.Millisecond)); !ok || p.Value != -1 {
+		t.Fatalf("out-of-order PriceAt = %+v, %v", p, ok)
+	}
+}
@@ -0,0 +1,350 @@
+// data.go 模块
+package polymarket
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dcsunny/polymarket-sdk/internal/httpx"
+)
+
+// DataClient 处理 Polymarket Data API（持仓、活动、成交、持有人、组合价值与排行榜）。
+type DataClient struct {
+	http *httpx.Client
+}
+
+func NewDataClient(http *httpx.Client) *DataClient {
+	return &DataClient{http: http}
+}
+
+// PositionsQuery 过滤持仓列表。
+type PositionsQuery struct {
+	// User 用户地址（必填，通常为 proxy wallet）。
+	User string
+
+	Limit  int
+	Offset int
+
+	// Markets 按 condition ID 过滤。
+	Markets []string
+	EventID *int
+	Title   string
+
+	SizeThreshold *float64
+	Redeemable    *bool
+	Mergeable     *bool
+
+	// SortBy 取值 CURRENT / INITIAL / TOKENS / CASHPNL / PERCENTPNL / TITLE / RESOLVING / PRICE / AVGPRICE。
+	SortBy        string
+	SortDirection string
+}
+
+// Positions 返回用户持仓。
+func (c *DataClient) Positions(ctx context.Context, q PositionsQuery) ([]*Position, error) {
+	if q.User == "" {
+		return nil, ErrInvalidArgument("user is required")
+	}
+	if q.Limit <= 0 {
+		q.Limit = 100
+	}
+
+	vals := url.Values{}
+	vals.Set("user", q.User)
+	vals.Set("limit", strconv.Itoa(q.Limit))
+	vals.Set("offset", strconv.Itoa(q.Offset))
+	if len(q.Markets) > 0 {
+		vals.Set("market", strings.Join(q.Markets, ","))
+	}
+	if q.EventID != nil {
+		vals.Set("eventId", strconv.Itoa(*q.EventID))
+	}
+	if q.Title != "" {
+		vals.Set("title", q.Title)
+	}
+	if q.SizeThreshold != nil {
+		vals.Set("sizeThreshold", strconv.FormatFloat(*q.SizeThreshold, 'f', -1, 64))
+	}
+	if q.Redeemable != nil {
+		vals.Set("redeemable", strconv.FormatBool(*q.Redeemable))
+	}
+	if q.Mergeable != nil {
+		vals.Set("mergeable", strconv.FormatBool(*q.Mergeable))
+	}
+	if q.SortBy != "" {
+		vals.Set("sortBy", q.SortBy)
+	}
+	if q.SortDirection != "" {
+		vals.Set("sortDirection", q.SortDirection)
+	}
+
+	var positions []*Position
+	if err := c.http.Do(ctx, http.MethodGet, "/positions", vals, nil, nil, &positions); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+// PositionsAll 逐页遍历用户持仓（语义同 RESTClient.EventsAll）。
+func (c *DataClient) PositionsAll(ctx context.Context, q PositionsQuery, opts PageOptions) iter.Seq2[*Position, error] {
+	if q.Limit <= 0 {
+		q.Limit = 100
+	}
+	return offsetPages(ctx, q.Limit, q.Offset, opts, func(ctx context.Context, offset int) ([]*Position, error) {
+		page := q
+		page.Offset = offset
+		return c.Positions(ctx, page)
+	})
+}
+
+// ActivityQuery 过滤用户活动。
+type ActivityQuery struct {
+	// User 用户地址（必填）。
+	User string
+
+	Limit  int
+	Offset int
+
+	Markets []string
+	EventID *int
+	// Types 取值 ActivityTypeTrade / ActivityTypeSplit 等。
+	Types []string
+	Side  string
+
+	// Start / End 为 Unix 秒。
+	Start int64
+	End   int64
+
+	// SortBy 取值 TIMESTAMP / TOKENS / CASH。
+	SortBy        string
+	SortDirection string
+}
+
+// Activity 返回用户的链上活动（成交、拆分、合并、赎回等）。
+func (c *DataClient) Activity(ctx context.Context, q ActivityQuery) ([]*Activity, error) {
+	if q.User == "" {
+		return nil, ErrInvalidArgument("user is required")
+	}
+	if q.Limit <= 0 {
+		q.Limit = 100
+	}
+
+	vals := url.Values{}
+	vals.Set("user", q.User)
+	vals.Set("limit", strconv.Itoa(q.Limit))
+	vals.Set("offset", strconv.Itoa(q.Offset))
+	if len(q.Markets) > 0 {
+		vals.Set("market", strings.Join(q.Markets, ","))
+	}
+	if q.EventID != nil {
+		vals.Set("eventId", strconv.Itoa(*q.EventID))
+	}
+	if len(q.Types) > 0 {
+		vals.Set("type", strings.Join(q.Types, ","))
+	}
+	if q.Side != "" {
+		vals.Set("side", q.Side)
+	}
+	if q.Start > 0 {
+		vals.Set("start", strconv.FormatInt(q.Start, 10))
+	}
+	if q.End > 0 {
+		vals.Set("end", strconv.FormatInt(q.End, 10))
+	}
+	if q.SortBy != "" {
+		vals.Set("sortBy", q.SortBy)
+	}
+	if q.SortDirection != "" {
+		vals.Set("sortDirection", q.SortDirection)
+	}
+
+	var activity []*Activity
+	if err := c.http.Do(ctx, http.MethodGet, "/activity", vals, nil, nil, &activity); err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
+
+// ActivityAll 逐页遍历用户活动。
+func (c *DataClient) ActivityAll(ctx context.Context, q ActivityQuery, opts PageOptions) iter.Seq2[*Activity, error] {
+	if q.Limit <= 0 {
+		q.Limit = 100
+	}
+	return offsetPages(ctx, q.Limit, q.Offset, opts, func(ctx context.Context, offset int) ([]*Activity, error) {
+		page := q
+		page.Offset = offset
+		return c.Activity(ctx, page)
+	})
+}
+
+// DataTradesQuery 过滤成交列表。
+type DataTradesQuery struct {
+	Limit  int
+	Offset int
+
+	User    string
+	Markets []string
+	EventID *int
+	Side    string
+
+	// TakerOnly 仅返回 taker 成交；为空时服务端默认 true。
+	TakerOnly *bool
+	// FilterType 取值 CASH / TOKENS，与 FilterAmount 一起过滤小额成交。
+	FilterType   string
+	FilterAmount *float64
+}
+
+// Trades 返回成交列表（可按用户或市场过滤，无需认证）。
+func (c *DataClient) Trades(ctx context.Context, q DataTradesQuery) ([]*DataTrade, error) {
+	if q.Limit <= 0 {
+		q.Limit = 100
+	}
+
+	vals := url.Values{}
+	vals.Set("limit", strconv.Itoa(q.Limit))
+	vals.Set("offset", strconv.Itoa(q.Offset))
+	if q.User != "" {
+		vals.Set("user", q.User)
+	}
+	if len(q.Markets) > 0 {
+		vals.Set("market", strings.Join(q.Markets, ","))
+	}
+	if q.EventID != nil {
+		vals.Set("eventId", strconv.Itoa(*q.EventID))
+	}
+	if q.Side != "" {
+		vals.Set("side", q.Side)
+	}
+	if q.TakerOnly != nil {
+		vals.Set("takerOnly", strconv.FormatBool(*q.TakerOnly))
+	}
+	if q.FilterType != "" {
+		vals.Set("filterType", q.FilterType)
+	}
+	if q.FilterAmount != nil {
+		vals.Set("filterAmount", strconv.FormatFloat(*q.FilterAmount, 'f', -1, 64))
+	}
+
+	var trades []*DataTrade
+	if err := c.http.Do(ctx, http.MethodGet, "/trades", vals, nil, nil, &trades); err != nil {
+		return nil, err
+	}
+	return trades, nil
+}
+
+// TradesAll 逐页遍历成交。
+func (c *DataClient) TradesAll(ctx context.Context, q DataTradesQuery, opts PageOptions) iter.Seq2[*DataTrade, error] {
+	if q.Limit <= 0 {
+		q.Limit = 100
+	}
+	return offsetPages(ctx, q.Limit, q.Offset, opts, func(ctx context.Context, offset int) ([]*DataTrade, error) {
+		page := q
+		page.Offset = offset
+		return c.Trades(ctx, page)
+	})
+}
+
+// HoldersQuery 过滤市场持有人。
+type HoldersQuery struct {
+	// Markets condition ID（必填）。
+	Markets []string
+	// Limit 每个 token 返回的持有人数量。
+	Limit      int
+	MinBalance *float64
+}
+
+// Holders 返回市场每个 token 的头部持有人。
+func (c *DataClient) Holders(ctx context.Context, q HoldersQuery) ([]*MarketHolders, error) {
+	if len(q.Markets) == 0 {
+		return nil, ErrInvalidArgument("markets is required")
+	}
+
+	vals := url.Values{}
+	vals.Set("market", strings.Join(q.Markets, ","))
+	if q.Limit > 0 {
+		vals.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.MinBalance != nil {
+		vals.Set("minBalance", strconv.FormatFloat(*q.MinBalance, 'f', -1, 64))
+	}
+
+	var holders []*MarketHolders
+	if err := c.http.Do(ctx, http.MethodGet, "/holders", vals, nil, nil, &holders); err != nil {
+		return nil, err
+	}
+	return holders, nil
+}
+
+// Value 返回用户持仓总价值；markets 非空时只统计这些市场。
+func (c *DataClient) Value(ctx context.Context, user string, markets ...string) (*PortfolioValue, error) {
+	if user == "" {
+		return nil, ErrInvalidArgument("user is required")
+	}
+
+	vals := url.Values{}
+	vals.Set("user", user)
+	if len(markets) > 0 {
+		vals.Set("market", strings.Join(markets, ","))
+	}
+
+	var values []*PortfolioValue
+	if err := c.http.Do(ctx, http.MethodGet, "/value", vals, nil, nil, &values); err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return &PortfolioValue{User: user}, nil
+	}
+	return values[0], nil
+}
+
+// LeaderboardQuery 控制排行榜查询。
+type LeaderboardQuery struct {
+	Limit  int
+	Offset int
+
+	// Category 如 OVERALL / POLITICS / SPORTS / CRYPTO；为空时为 OVERALL。
+	Category string
+	// TimePeriod 取值 DAY / WEEK / MONTH / ALL。
+	TimePeriod string
+	// OrderBy 取值 PNL / VOL。
+	OrderBy string
+
+	// User / UserName 查询单个用户的排名。
+	User     string
+	UserName string
+}
+
+// Leaderboard 返回交易者排行榜。
+func (c *DataClient) Leaderboard(ctx context.Context, q LeaderboardQuery) ([]*LeaderboardEntry, error) {
+	if q.Limit <= 0 {
+		q.Limit = 25
+	}
+
+	vals := url.Values{}
+	vals.Set("limit", strconv.Itoa(q.Limit))
+	vals.Set("offset", strconv.Itoa(q.Offset))
+	if q.Category != "" {
+		vals.Set("category", q.Category)
+	}
+	if q.TimePeriod != "" {
+		vals.Set("timePeriod", q.TimePeriod)
+	}
+	if q.OrderBy != "" {
+		vals.Set("orderBy", q.OrderBy)
+	}
+	if q.User != "" {
+		vals.Set("user", q.User)
+	}
+	if q.UserName != "" {
+		vals.Set("userName", q.UserName)
+	}
+
+	var entries []*LeaderboardEntry
+	if err := c.http.Do(ctx, http.MethodGet, "/v1/leaderboard", vals, nil, nil, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
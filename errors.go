@@ -5,3 +5,64 @@ import ierr "github.com/dcsunny/polymarket-sdk/internal/errors"
 
 // APIError 表示非 2xx 响应。
 type APIError = ierr.APIError
+
+// 哨兵错误：REST 错误（*APIError）与订单响应错误（*OrderError）都支持 errors.Is 匹配。
+//
+//	if errors.Is(err, pm.ErrInsufficientBalance) { ... }
+var (
+	ErrBadRequest   = ierr.ErrBadRequest
+	ErrUnauthorized = ierr.ErrUnauthorized
+	ErrForbidden    = ierr.ErrForbidden
+	ErrNotFound     = ierr.ErrNotFound
+	ErrRateLimited  = ierr.ErrRateLimited
+	ErrServer       = ierr.ErrServer
+
+	ErrInsufficientBalance = ierr.ErrInsufficientBalance
+	ErrInvalidTickSize     = ierr.ErrInvalidTickSize
+	ErrInvalidOrderSize    = ierr.ErrInvalidOrderSize
+	ErrInvalidExpiration   = ierr.ErrInvalidExpiration
+	ErrDuplicateOrder      = ierr.ErrDuplicateOrder
+	ErrOrderNotFilled      = ierr.ErrOrderNotFilled
+	ErrMarketClosed        = ierr.ErrMarketClosed
+	ErrMarketNotFound      = ierr.ErrMarketNotFound
+	ErrMarketNotReady      = ierr.ErrMarketNotReady
+	ErrOrderNotFound       = ierr.ErrOrderNotFound
+	ErrClosedOnly          = ierr.ErrClosedOnly
+	ErrInvalidSignature    = ierr.ErrInvalidSignature
+)
+
+// ClassifyError 根据 HTTP 状态码、错误码与文案推断错误类别；status 为 0 表示非 HTTP 场景。
+// 无法识别时返回 nil。
+func ClassifyError(status int, code, message string) error {
+	return ierr.Classify(status, code, message)
+}
+
+// OrderError 表示下单/撤单响应体中携带的业务错误（HTTP 2xx 但 success=false 或 not_canceled）。
+type OrderError struct {
+	OrderID string
+	Status  string
+	Message string
+	// Kind 为推断出的类别（哨兵错误）；无法识别时为 nil。
+	Kind error
+}
+
+func (e *OrderError) Error() string {
+	if e.OrderID != "" {
+		return "order error: id=" + e.OrderID + " msg=" + e.Message
+	}
+	return "order error: msg=" + e.Message
+}
+
+// Unwrap 返回错误类别，便于 errors.Is 匹配。
+func (e *OrderError) Unwrap() error {
+	return e.Kind
+}
+
+func newOrderError(orderID, status, msg string) *OrderError {
+	return &OrderError{
+		OrderID: orderID,
+		Status:  status,
+		Message: msg,
+		Kind:    ierr.Classify(0, "", msg),
+	}
+}
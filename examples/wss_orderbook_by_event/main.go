@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"strings"
 
 	pm "github.com/dcsunny/polymarket-sdk"
 	"github.com/joho/godotenv"
@@ -84,50 +83,12 @@ func main() {
 }
 
 func extractAssetID(event *pm.Event) string {
-	for _, raw := range event.Markets {
-		m, ok := raw.(map[string]interface{})
-		if !ok {
-			continue
+	for _, m := range event.Markets {
+		if id, ok := m.TokenForOutcome("Yes"); ok {
+			return id
 		}
-		if v, ok := m["clobTokenIds"]; ok {
-			if id := pickToken(v); id != "" {
-				return id
-			}
-		}
-		if v, ok := m["clob_token_ids"]; ok {
-			if id := pickToken(v); id != "" {
-				return id
-			}
-		}
-	}
-	return ""
-}
-
-func pickToken(v interface{}) string {
-	switch t := v.(type) {
-	case []interface{}:
-		for _, item := range t {
-			if s, ok := item.(string); ok && s != "" {
-				return s
-			}
-		}
-	case []string:
-		if len(t) > 0 {
-			return t[0]
-		}
-	case string:
-		if t == "" {
-			return ""
-		}
-		if strings.HasPrefix(t, "[") {
-			var arr []string
-			if err := json.Unmarshal([]byte(t), &arr); err == nil && len(arr) > 0 {
-				return arr[0]
-			}
-		}
-		parts := strings.Split(t, ",")
-		if len(parts) > 0 {
-			return strings.TrimSpace(parts[0])
+		if len(m.ClobTokenIds) > 0 {
+			return m.ClobTokenIds[0]
 		}
 	}
 	return ""
@@ -1,7 +1,10 @@
 // api_error.go 模块
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // APIError 表示非 2xx 响应。
 type APIError struct {
@@ -10,6 +13,11 @@ type APIError struct {
 	Message   string
 	RequestID string
 	Body      string
+
+	// Kind 是由状态码/错误码/文案推断出的类别（哨兵错误，例如 ErrRateLimited）；无法识别时为 nil。
+	Kind error
+	// RetryAfter 为 429/503 响应中 Retry-After 头给出的等待时间（若有）。
+	RetryAfter time.Duration
 }
 
 func (e *APIError) Error() string {
@@ -24,3 +32,23 @@ func (e *APIError) Error() string {
 	}
 	return fmt.Sprintf("api error: status=%d", e.Status)
 }
+
+// Is 支持 errors.Is：既匹配具体类别（Kind），也匹配状态码对应的通用类别。
+// 例如 400 + "not enough balance / allowance" 同时满足 ErrInsufficientBalance 与 ErrBadRequest。
+func (e *APIError) Is(target error) bool {
+	if e == nil || target == nil {
+		return false
+	}
+	if e.Kind == target {
+		return true
+	}
+	return StatusKind(e.Status) == target
+}
+
+// Unwrap 返回错误类别，便于 errors.Is 沿链匹配。
+func (e *APIError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Kind
+}
@@ -0,0 +1,87 @@
+// kinds.go 模块
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// 哨兵错误：可配合 errors.Is 判断 APIError / OrderError 的类别。
+var (
+	ErrBadRequest   = errors.New("polymarket: bad request")
+	ErrUnauthorized = errors.New("polymarket: unauthorized")
+	ErrForbidden    = errors.New("polymarket: forbidden")
+	ErrNotFound     = errors.New("polymarket: not found")
+	ErrRateLimited  = errors.New("polymarket: rate limited")
+	ErrServer       = errors.New("polymarket: server error")
+
+	ErrInsufficientBalance = errors.New("polymarket: not enough balance / allowance")
+	ErrInvalidTickSize     = errors.New("polymarket: invalid tick size")
+	ErrInvalidOrderSize    = errors.New("polymarket: invalid order size")
+	ErrInvalidExpiration   = errors.New("polymarket: invalid expiration")
+	ErrDuplicateOrder      = errors.New("polymarket: duplicated order")
+	ErrOrderNotFilled      = errors.New("polymarket: order could not be filled")
+	ErrMarketClosed        = errors.New("polymarket: market closed")
+	ErrMarketNotFound      = errors.New("polymarket: market not found")
+	ErrMarketNotReady      = errors.New("polymarket: market not ready")
+	ErrOrderNotFound       = errors.New("polymarket: order not found")
+	ErrClosedOnly          = errors.New("polymarket: closed-only mode")
+	ErrInvalidSignature    = errors.New("polymarket: invalid signature")
+)
+
+// messageKinds 按顺序匹配服务端错误码/文案（小写子串）；越具体的规则越靠前。
+var messageKinds = []struct {
+	needles []string
+	kind    error
+}{
+	{[]string{"not enough balance / allowance", "not enough balance"}, ErrInsufficientBalance},
+	{[]string{"breaks minimum tick size rule", "invalid_order_min_tick_size", "invalid tick size"}, ErrInvalidTickSize},
+	{[]string{"closed only", "closed-only", "closed_only"}, ErrClosedOnly},
+	{[]string{"lower than the minimum", "min_size", "invalid size", "size too small"}, ErrInvalidOrderSize},
+	{[]string{"invalid expiration", "order_expiration"}, ErrInvalidExpiration},
+	{[]string{"is invalid. duplicated", "invalid_order_duplicated", "duplicated order"}, ErrDuplicateOrder},
+	{[]string{"order couldn't be fully filled", "fok_order_not_filled", "no orders found to match"}, ErrOrderNotFilled},
+	{[]string{"not yet ready", "market_not_ready"}, ErrMarketNotReady},
+	{[]string{"order not found", "order does not exist", "order_not_found"}, ErrOrderNotFound},
+	{[]string{"market is closed", "market closed"}, ErrMarketClosed},
+	{[]string{"market not found", "no orderbook exists", "orderbook does not exist"}, ErrMarketNotFound},
+	{[]string{"invalid signature"}, ErrInvalidSignature},
+	{[]string{"rate limit", "too many requests"}, ErrRateLimited},
+	{[]string{"unauthorized", "invalid api key"}, ErrUnauthorized},
+}
+
+// Classify 根据 HTTP 状态码、错误码与错误文案推断错误类别（哨兵错误）。
+// status 为 0 表示无 HTTP 状态（例如订单响应中的 errorMsg）。无法识别时返回 nil。
+func Classify(status int, code, message string) error {
+	text := strings.ToLower(code + " " + message)
+	if strings.TrimSpace(text) != "" {
+		for _, mk := range messageKinds {
+			for _, n := range mk.needles {
+				if strings.Contains(text, n) {
+					return mk.kind
+				}
+			}
+		}
+	}
+	return StatusKind(status)
+}
+
+// StatusKind 返回 HTTP 状态码对应的通用类别；2xx 或未知时返回 nil。
+func StatusKind(status int) error {
+	switch {
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case status == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case status == http.StatusForbidden:
+		return ErrForbidden
+	case status == http.StatusNotFound:
+		return ErrNotFound
+	case status >= 500:
+		return ErrServer
+	case status >= 400:
+		return ErrBadRequest
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// kinds_test.go 模块
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		status int
+		msg    string
+		want   error
+	}{
+		{0, "not enough balance / allowance", ErrInsufficientBalance},
+		{400, "Not enough balance / allowance", ErrInsufficientBalance},
+		{0, "order couldn't be fully filled. FOK orders are fully filled or killed.", ErrOrderNotFilled},
+		{0, "no orders found to match with FAK order. FAK orders are partially filled or killed if no match is found.", ErrOrderNotFilled},
+		{0, "market is closed", ErrMarketClosed},
+		{400, "No orderbook exists for the requested token id", ErrMarketNotFound},
+		{404, "market not found", ErrMarketNotFound},
+		{0, "order is invalid. Price (0.555) breaks minimum tick size rule: 0.01", ErrInvalidTickSize},
+		{0, "INVALID_ORDER_MIN_TICK_SIZE", ErrInvalidTickSize},
+		{0, "order 0xabc is invalid. Duplicated.", ErrDuplicateOrder},
+		{0, "INVALID_ORDER_DUPLICATED", ErrDuplicateOrder},
+		{0, "order is invalid. Size lower than the minimum", ErrInvalidOrderSize},
+		{0, "order not found", ErrOrderNotFound},
+
+		// 只出现宽泛关键词的文案不应被误判。
+		{400, "allowance update pending, retry later", ErrBadRequest},
+		{400, "order not filled yet", ErrBadRequest},
+		{0, "allowance update pending", nil},
+		{400, "duplicate nonce in request headers", ErrBadRequest},
+		{0, "tick size cache refreshed", nil},
+
+		{http.StatusTooManyRequests, "", ErrRateLimited},
+		{http.StatusServiceUnavailable, "", ErrServer},
+		{http.StatusOK, "", nil},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.status, "", tt.msg); got != tt.want {
+			t.Errorf("Classify(%d, %q) = %v, want %v", tt.status, tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	err := error(&APIError{Status: 400, Message: "No orderbook exists for the requested token id", Kind: ErrMarketNotFound})
+	if !errors.Is(err, ErrMarketNotFound) || !errors.Is(err, ErrBadRequest) {
+		t.Fatalf("errors.Is failed for %v", err)
+	}
+	if errors.Is(err, ErrMarketClosed) {
+		t.Fatalf("%v must not match ErrMarketClosed", err)
+	}
+}
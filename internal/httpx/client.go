@@ -8,13 +8,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	ierr "github.com/dcsunny/polymarket-sdk/internal/errors"
+	"github.com/dcsunny/polymarket-sdk/internal/metrics"
 )
 
 // Client 是一个带有基础 URL 和默认值的轻量级 HTTP 封装。
@@ -23,47 +24,54 @@ type Client struct {
 	http    *http.Client
 	headers map[string]string
 	debug   bool
+
+	service string
+	metrics metrics.Recorder
 }
 
 // New 创建新的 HTTP 客户端。
 func New(baseURL string, timeout time.Duration, proxy string, userAgent string, debug bool) (*Client, error) {
+	return NewWithOptions(baseURL, Options{
+		Timeout:   timeout,
+		Proxy:     proxy,
+		UserAgent: userAgent,
+		Debug:     debug,
+	})
+}
+
+// NewWithOptions 按 Options 创建新的 HTTP 客户端。
+func NewWithOptions(baseURL string, opts Options) (*Client, error) {
 	if baseURL == "" {
 		return nil, errors.New("baseURL is required")
 	}
 
-	transport := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		DialContext: (&net.Dialer{
-			Timeout:   10 * time.Second,
-			KeepAlive: 30 * time.Second,
-		}).DialContext,
-		MaxIdleConns:          100,
-		IdleConnTimeout:       90 * time.Second,
-		TLSHandshakeTimeout:   10 * time.Second,
-		ExpectContinueTimeout: 1 * time.Second,
-	}
-	if proxy != "" {
-		if proxyURL, err := url.Parse(proxy); err == nil {
-			transport.Proxy = http.ProxyURL(proxyURL)
-		}
-	}
-
 	h := map[string]string{}
-	if userAgent != "" {
-		h["User-Agent"] = userAgent
+	if opts.UserAgent != "" {
+		h["User-Agent"] = opts.UserAgent
 	}
 
 	return &Client{
 		baseURL: strings.TrimRight(baseURL, "/"),
-		http: &http.Client{
-			Timeout:   timeout,
-			Transport: transport,
-		},
+		http:    NewHTTPClient(opts),
 		headers: h,
-		debug:   debug,
+		debug:   opts.Debug,
+		metrics: metrics.Nop{},
 	}, nil
 }
 
+// SetMetrics 设置指标记录器；service 作为指标中的服务名（如 gamma、clob）。
+func (c *Client) SetMetrics(service string, r metrics.Recorder) {
+	c.service = service
+	c.metrics = metrics.OrNop(r)
+}
+
+// SetTransport 替换底层 RoundTripper（例如录制/回放、测试桩）。
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	if rt != nil {
+		c.http.Transport = rt
+	}
+}
+
 // Do 发送 JSON 请求。
 func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body any, headers map[string]string, out any) error {
 	var payload []byte
@@ -115,21 +123,23 @@ func (c *Client) DoRaw(ctx context.Context, method, path string, query url.Value
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	start := time.Now()
 	resp, err := c.http.Do(req)
 	if err != nil {
+		c.metrics.ObserveHTTPRequest(c.service, method, endpointLabel(path), 0, time.Since(start), err)
 		return err
 	}
 	defer resp.Body.Close()
 
 	respBytes, err := io.ReadAll(resp.Body)
+	if err == nil && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
+		err = parseAPIError(resp, respBytes)
+	}
+	c.metrics.ObserveHTTPRequest(c.service, method, endpointLabel(path), resp.StatusCode, time.Since(start), err)
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return parseAPIError(resp, respBytes)
-	}
-
 	if out == nil {
 		return nil
 	}
@@ -169,9 +179,41 @@ func (c *Client) resolveURL(path string, query url.Values) (string, error) {
 	return base.String(), nil
 }
 
+// endpointLabel 将路径中的 ID 类片段（订单哈希、condition id、数字 id 等）替换为 `:id`，
+// 避免指标标签基数膨胀。
+func endpointLabel(path string) string {
+	if path == "" {
+		return "/"
+	}
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		if isIDSegment(p) {
+			parts[i] = ":id"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+func isIDSegment(s string) bool {
+	if s == "" {
+		return false
+	}
+	if strings.HasPrefix(s, "0x") && len(s) > 10 {
+		return true
+	}
+	digits := 0
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	return digits == len(s) || (len(s) >= 16 && digits > 0)
+}
+
 func parseAPIError(resp *http.Response, body []byte) error {
 	errObj := struct {
 		Error     string `json:"error"`
+		ErrorMsg  string `json:"errorMsg"`
 		Message   string `json:"message"`
 		Code      string `json:"code"`
 		RequestID string `json:"request_id"`
@@ -184,12 +226,33 @@ func parseAPIError(resp *http.Response, body []byte) error {
 	if msg == "" {
 		msg = errObj.Error
 	}
+	if msg == "" {
+		msg = errObj.ErrorMsg
+	}
 
 	return &ierr.APIError{
-		Status:    resp.StatusCode,
-		Code:      errObj.Code,
-		Message:   msg,
-		RequestID: errObj.RequestID,
-		Body:      string(body),
+		Status:     resp.StatusCode,
+		Code:       errObj.Code,
+		Message:    msg,
+		RequestID:  errObj.RequestID,
+		Body:       string(body),
+		Kind:       ierr.Classify(resp.StatusCode, errObj.Code, msg),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+}
+
+// parseRetryAfter 解析 Retry-After（秒数或 HTTP 日期）。
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
 	}
+	return 0
 }
@@ -0,0 +1,92 @@
+// transport.go 模块
+package httpx
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// 内置 Transport 的默认连接池参数。
+const (
+	DefaultMaxIdleConns    = 100
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// Options 描述 HTTP 客户端的构造参数。
+type Options struct {
+	Timeout   time.Duration
+	Proxy     string
+	UserAgent string
+	Debug     bool
+
+	// Client 非空时直接使用（浅拷贝），Timeout / Transport 非零时覆盖其对应字段。
+	Client *http.Client
+	// Transport 非空时替换底层 RoundTripper，此时 Proxy / TLSConfig / 连接池参数不生效。
+	Transport http.RoundTripper
+	// TLSConfig 作用于内置 Transport（mTLS、私有 CA 等）。
+	TLSConfig *tls.Config
+
+	// 内置 Transport 的连接池参数；0 使用默认值（MaxIdleConnsPerHost / MaxConnsPerHost 为 0 时沿用 net/http 默认）。
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+}
+
+// NewTransport 按 Options 构建 http.Transport。
+func NewTransport(opts Options) *http.Transport {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          DefaultMaxIdleConns,
+		MaxIdleConnsPerHost:   opts.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       opts.MaxConnsPerHost,
+		IdleConnTimeout:       DefaultIdleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if opts.MaxIdleConns > 0 {
+		transport.MaxIdleConns = opts.MaxIdleConns
+	}
+	if opts.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	if opts.TLSConfig != nil {
+		transport.TLSClientConfig = opts.TLSConfig.Clone()
+	}
+	if opts.Proxy != "" {
+		if proxyURL, err := url.Parse(opts.Proxy); err == nil {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+	return transport
+}
+
+// NewHTTPClient 按 Options 构建 http.Client。
+func NewHTTPClient(opts Options) *http.Client {
+	if opts.Client != nil {
+		cp := *opts.Client
+		if opts.Timeout > 0 {
+			cp.Timeout = opts.Timeout
+		}
+		if opts.Transport != nil {
+			cp.Transport = opts.Transport
+		}
+		return &cp
+	}
+
+	var rt http.RoundTripper = opts.Transport
+	if rt == nil {
+		rt = NewTransport(opts)
+	}
+	return &http.Client{
+		Timeout:   opts.Timeout,
+		Transport: rt,
+	}
+}
@@ -0,0 +1,54 @@
+// metrics.go 模块
+package metrics
+
+import "time"
+
+// Recorder 接收 SDK 运行时指标。
+// 实现必须是并发安全的，且不应阻塞调用方（会在请求路径与 websocket 读循环中被调用）。
+type Recorder interface {
+	// ObserveHTTPRequest 记录一次 HTTP 请求。status 为 0 表示请求未拿到响应（网络错误等）。
+	ObserveHTTPRequest(service, method, endpoint string, status int, d time.Duration, err error)
+	// IncWSSMessage 记录一条 websocket 消息（channel 为 market/user/rtds 等）。
+	IncWSSMessage(channel, eventType string)
+	// IncReconnect 记录一次 websocket 重连。
+	IncReconnect(channel string)
+	// ObserveHandler 记录一次消息处理器耗时。
+	ObserveHandler(channel, eventType string, d time.Duration)
+	// ObserveOrderSubmit 记录下单从提交到服务端确认的耗时。
+	ObserveOrderSubmit(endpoint string, d time.Duration, success bool)
+	// ObserveCacheLookup 记录一次缓存查询是否命中。
+	ObserveCacheLookup(cache string, hit bool)
+}
+
+// StreamDropRecorder 是 Recorder 的可选扩展：实现后接收流式订阅的丢弃计数。
+// 单独定义以免已有的 Recorder 实现因新增方法而无法编译。
+type StreamDropRecorder interface {
+	// IncStreamDrop 记录流式订阅因缓冲区溢出丢弃的一条消息。
+	IncStreamDrop(channel, subscription string)
+}
+
+// IncStreamDrop 在 r 实现 StreamDropRecorder 时记录一次丢弃，否则忽略。
+func IncStreamDrop(r Recorder, channel, subscription string) {
+	if d, ok := r.(StreamDropRecorder); ok {
+		d.IncStreamDrop(channel, subscription)
+	}
+}
+
+// Nop 是不做任何事情的 Recorder。
+type Nop struct{}
+
+func (Nop) ObserveHTTPRequest(string, string, string, int, time.Duration, error) {}
+func (Nop) IncWSSMessage(string, string)                                         {}
+func (Nop) IncReconnect(string)                                                  {}
+func (Nop) ObserveHandler(string, string, time.Duration)                         {}
+func (Nop) ObserveOrderSubmit(string, time.Duration, bool)                       {}
+func (Nop) ObserveCacheLookup(string, bool)                                      {}
+func (Nop) IncStreamDrop(string, string)                                         {}
+
+// OrNop 在 r 为 nil 时返回 Nop。
+func OrNop(r Recorder) Recorder {
+	if r == nil {
+		return Nop{}
+	}
+	return r
+}
@@ -0,0 +1,30 @@
+// metrics.go 模块
+package polymarket
+
+import "github.com/dcsunny/polymarket-sdk/internal/metrics"
+
+// Metrics 接收 SDK 运行时指标（HTTP 请求、websocket 消息、重连、处理器耗时、下单耗时、缓存命中）。
+// 默认为 no-op；可使用 NewPrometheusMetrics 或自行实现。实现必须是并发安全的。
+type Metrics = metrics.Recorder
+
+// StreamDropMetrics 是 Metrics 的可选扩展：Metrics 实现同时实现它时，记录流式订阅因缓冲区溢出丢弃的消息。
+type StreamDropMetrics = metrics.StreamDropRecorder
+
+// NopMetrics 返回不做任何事情的 Metrics。
+func NopMetrics() Metrics {
+	return metrics.Nop{}
+}
+
+// 指标中使用的 websocket channel 名称。
+const (
+	MetricsChannelMarket = "market"
+	MetricsChannelUser   = "user"
+	MetricsChannelRTDS   = "rtds"
+)
+
+// 指标中使用的缓存名称。
+const (
+	MetricsCacheTickSize = "tick_size"
+	MetricsCacheNegRisk  = "neg_risk"
+	MetricsCacheFeeRate  = "fee_rate"
+)
@@ -0,0 +1,216 @@
+// metrics_prometheus.go 模块
+package polymarket
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPrometheusBuckets 延迟直方图的默认分桶（秒）。
+var DefaultPrometheusBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// PrometheusMetrics 是内存中的 Metrics 实现，通过 Handler 以 Prometheus 文本格式暴露。
+// 不依赖 prometheus client 库；可直接挂载到本地 http.ServeMux 的 /metrics 上。
+type PrometheusMetrics struct {
+	namespace string
+	buckets   []float64
+
+	mu         sync.Mutex
+	counters   map[string]*promCounter
+	histograms map[string]*promHistogram
+}
+
+type promCounter struct {
+	name   string
+	help   string
+	series map[string]float64
+}
+
+type promHistogram struct {
+	name   string
+	help   string
+	series map[string]*promHistogramSeries
+}
+
+type promHistogramSeries struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewPrometheusMetrics 创建 PrometheusMetrics。namespace 为空时使用 "polymarket"。
+func NewPrometheusMetrics(namespace string) *PrometheusMetrics {
+	if namespace == "" {
+		namespace = "polymarket"
+	}
+	return &PrometheusMetrics{
+		namespace:  namespace,
+		buckets:    DefaultPrometheusBuckets,
+		counters:   make(map[string]*promCounter),
+		histograms: make(map[string]*promHistogram),
+	}
+}
+
+// ObserveHTTPRequest 实现 Metrics。
+func (m *PrometheusMetrics) ObserveHTTPRequest(service, method, endpoint string, status int, d time.Duration, err error) {
+	labels := promLabels("service", service, "method", method, "endpoint", endpoint)
+	m.addCounter("http_requests_total", "HTTP requests by endpoint and status.",
+		promLabels("service", service, "method", method, "endpoint", endpoint, "status", strconv.Itoa(status)), 1)
+	if err != nil {
+		m.addCounter("http_request_errors_total", "HTTP requests that returned an error.", labels, 1)
+	}
+	m.observe("http_request_duration_seconds", "HTTP request latency.", labels, d)
+}
+
+// IncWSSMessage 实现 Metrics。
+func (m *PrometheusMetrics) IncWSSMessage(channel, eventType string) {
+	m.addCounter("ws_messages_total", "WebSocket messages received by channel and event type.",
+		promLabels("channel", channel, "event_type", eventType), 1)
+}
+
+// IncReconnect 实现 Metrics。
+func (m *PrometheusMetrics) IncReconnect(channel string) {
+	m.addCounter("ws_reconnects_total", "WebSocket reconnects by channel.", promLabels("channel", channel), 1)
+}
+
+// ObserveHandler 实现 Metrics。
+func (m *PrometheusMetrics) ObserveHandler(channel, eventType string, d time.Duration) {
+	m.observe("ws_handler_duration_seconds", "WebSocket message handler latency.",
+		promLabels("channel", channel, "event_type", eventType), d)
+}
+
+// ObserveOrderSubmit 实现 Metrics。
+func (m *PrometheusMetrics) ObserveOrderSubmit(endpoint string, d time.Duration, success bool) {
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+	m.observe("order_submit_duration_seconds", "Order submit-to-ack latency.",
+		promLabels("endpoint", endpoint, "result", result), d)
+}
+
+// ObserveCacheLookup 实现 Metrics。
+func (m *PrometheusMetrics) ObserveCacheLookup(cache string, hit bool) {
+	result := "hit"
+	if !hit {
+		result = "miss"
+	}
+	m.addCounter("cache_lookups_total", "Cache lookups by cache and result.",
+		promLabels("cache", cache, "result", result), 1)
+}
+
+// IncStreamDrop 实现 StreamDropMetrics。
+func (m *PrometheusMetrics) IncStreamDrop(channel, subscription string) {
+	m.addCounter("stream_dropped_total", "Stream messages dropped on buffer overflow by subscription.",
+		promLabels("channel", channel, "subscription", subscription), 1)
+}
+
+// Handler 返回以 Prometheus 文本格式输出所有指标的 http.Handler。
+func (m *PrometheusMetrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = m.Write(w)
+	})
+}
+
+// Write 将所有指标以 Prometheus 文本格式写入 w。
+func (m *PrometheusMetrics) Write(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var sb strings.Builder
+	for _, name := range sortedKeys(m.counters) {
+		c := m.counters[name]
+		fmt.Fprintf(&sb, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+		for _, labels := range sortedKeys(c.series) {
+			fmt.Fprintf(&sb, "%s{%s} %s\n", c.name, labels, formatPromFloat(c.series[labels]))
+		}
+	}
+	for _, name := range sortedKeys(m.histograms) {
+		h := m.histograms[name]
+		fmt.Fprintf(&sb, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+		for _, labels := range sortedKeys(h.series) {
+			s := h.series[labels]
+			for i, b := range m.buckets {
+				fmt.Fprintf(&sb, "%s_bucket{%s,le=\"%s\"} %d\n", h.name, labels, formatPromFloat(b), s.counts[i])
+			}
+			fmt.Fprintf(&sb, "%s_bucket{%s,le=\"+Inf\"} %d\n", h.name, labels, s.count)
+			fmt.Fprintf(&sb, "%s_sum{%s} %s\n", h.name, labels, formatPromFloat(s.sum))
+			fmt.Fprintf(&sb, "%s_count{%s} %d\n", h.name, labels, s.count)
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (m *PrometheusMetrics) addCounter(name, help, labels string, v float64) {
+	full := m.namespace + "_" + name
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.counters[full]
+	if !ok {
+		c = &promCounter{name: full, help: help, series: make(map[string]float64)}
+		m.counters[full] = c
+	}
+	c.series[labels] += v
+}
+
+func (m *PrometheusMetrics) observe(name, help, labels string, d time.Duration) {
+	full := m.namespace + "_" + name
+	v := d.Seconds()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.histograms[full]
+	if !ok {
+		h = &promHistogram{name: full, help: help, series: make(map[string]*promHistogramSeries)}
+		m.histograms[full] = h
+	}
+	s, ok := h.series[labels]
+	if !ok {
+		s = &promHistogramSeries{counts: make([]uint64, len(m.buckets))}
+		h.series[labels] = s
+	}
+	for i, b := range m.buckets {
+		if v <= b {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += v
+}
+
+// promLabels 将 k/v 对格式化为 Prometheus 标签串（k1="v1",k2="v2"）。
+func promLabels(kv ...string) string {
+	var sb strings.Builder
+	for i := 0; i+1 < len(kv); i += 2 {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(kv[i])
+		sb.WriteString(`="`)
+		sb.WriteString(promEscaper.Replace(kv[i+1]))
+		sb.WriteByte('"')
+	}
+	return sb.String()
+}
+
+var promEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatPromFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
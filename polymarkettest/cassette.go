@@ -0,0 +1,424 @@
+// cassette.go 模块
+package polymarkettest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CassetteMode 决定 Cassette 录制还是回放。
+type CassetteMode int
+
+const (
+	// ModeReplay 从 cassette 文件回放响应（默认）。
+	ModeReplay CassetteMode = iota
+	// ModeRecord 通过真实传输层发送请求并录制（Save 时写入文件）。
+	ModeRecord
+)
+
+// ErrCassetteMiss 严格回放模式下请求未命中任何记录。
+var ErrCassetteMiss = errors.New("polymarkettest: cassette miss")
+
+// Redacted 录制时替换敏感信息的占位值。
+const Redacted = "REDACTED"
+
+// 默认脱敏的请求/响应头（大小写不敏感）。
+var defaultScrubHeaders = []string{
+	"POLY_API_KEY", "POLY_PASSPHRASE", "POLY_SIGNATURE", "POLY_TIMESTAMP", "POLY_NONCE",
+	"POLY_BUILDER_API_KEY", "POLY_BUILDER_PASSPHRASE", "POLY_BUILDER_SIGNATURE", "POLY_BUILDER_TIMESTAMP",
+	"Authorization", "Cookie", "Set-Cookie",
+}
+
+// 默认脱敏的 JSON 字段（请求体与响应体，任意层级）。
+var defaultScrubFields = []string{"apiKey", "api_key", "apiKeys", "secret", "passphrase", "owner", "order_owner"}
+
+// 默认匹配时忽略的易变 JSON 字段（每次签名都会变化）。
+var defaultIgnoreFields = []string{"salt", "signature", "timestamp", "expiration"}
+
+// 默认匹配时忽略的易变查询参数。
+var defaultIgnoreQuery = []string{"ts", "timestamp", "_"}
+
+// CassetteOptions 配置 Cassette。
+type CassetteOptions struct {
+	Mode CassetteMode
+
+	// Transport 录制时使用的真实传输层；为空使用 http.DefaultTransport。
+	// 回放时非严格模式下未命中的请求也会转发到这里（为空则返回 404）。
+	Transport http.RoundTripper
+
+	// Strict 回放时未命中的请求直接返回错误（不转发、不伪造响应）。
+	Strict bool
+
+	// ScrubHeaders / ScrubFields 在默认列表之外追加需要脱敏的头与 JSON 字段。
+	ScrubHeaders []string
+	ScrubFields  []string
+
+	// IgnoreQuery / IgnoreFields 在默认列表之外追加匹配时忽略的查询参数与 JSON 字段。
+	IgnoreQuery  []string
+	IgnoreFields []string
+}
+
+// Interaction 为一次录制的请求/响应。
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest 录制的请求。
+type CassetteRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// CassetteResponse 录制的响应。
+type CassetteResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type cassetteFile struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Cassette 是可插入 Config.HTTPTransport 的录制/回放 RoundTripper：
+//
+//	c, _ := polymarkettest.NewCassette("testdata/orders.json", polymarkettest.CassetteOptions{Strict: true})
+//	sdk, _ := polymarket.New(polymarket.Config{HTTPTransport: c})
+//
+// 匹配基于 method、host、path、查询参数与 JSON 请求体，忽略认证头与时间戳等易变字段。
+type Cassette struct {
+	path string
+	opts CassetteOptions
+
+	scrubHeaders map[string]bool
+	scrubFields  map[string]bool
+	ignoreQuery  map[string]bool
+	ignoreFields map[string]bool
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	keys         []string
+	used         []bool
+	unmatched    []string
+}
+
+// NewCassette 创建 Cassette。回放模式下 path 必须存在。
+func NewCassette(path string, opts CassetteOptions) (*Cassette, error) {
+	c := &Cassette{
+		path:         path,
+		opts:         opts,
+		scrubHeaders: lowerSet(defaultScrubHeaders, opts.ScrubHeaders),
+		scrubFields:  lowerSet(defaultScrubFields, opts.ScrubFields),
+		ignoreQuery:  lowerSet(defaultIgnoreQuery, opts.IgnoreQuery),
+		ignoreFields: lowerSet(defaultIgnoreFields, opts.IgnoreFields),
+	}
+	// 脱敏后的字段在回放时与真实请求不一致，匹配时同样忽略。
+	for k := range c.scrubFields {
+		c.ignoreFields[k] = true
+	}
+
+	if opts.Mode == ModeRecord {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("polymarkettest: load cassette: %w", err)
+	}
+	var f cassetteFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("polymarkettest: decode cassette %s: %w", path, err)
+	}
+	for _, it := range f.Interactions {
+		c.add(it)
+	}
+	return c, nil
+}
+
+// RoundTrip 实现 http.RoundTripper。
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.opts.Mode == ModeRecord {
+		return c.record(req, body)
+	}
+
+	key := c.matchKey(req.Method, req.URL, string(body))
+	c.mu.Lock()
+	it := c.lookupLocked(key)
+	if it == nil {
+		c.unmatched = append(c.unmatched, req.Method+" "+req.URL.String())
+	}
+	c.mu.Unlock()
+
+	if it != nil {
+		return it.Response.toHTTP(req), nil
+	}
+	if c.opts.Strict {
+		return nil, fmt.Errorf("%w: %s %s (%s)", ErrCassetteMiss, req.Method, req.URL, c.path)
+	}
+	if c.opts.Transport != nil {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		return c.opts.Transport.RoundTrip(req)
+	}
+	resp := CassetteResponse{
+		Status: http.StatusNotFound,
+		Header: http.Header{"Content-Type": {"application/json"}},
+		Body:   `{"error":"polymarkettest: no cassette interaction"}`,
+	}
+	return resp.toHTTP(req), nil
+}
+
+// lookupLocked 返回第一条未使用的匹配记录；全部用过时复用最后一条匹配记录（轮询场景）。
+func (c *Cassette) lookupLocked(key string) *Interaction {
+	last := -1
+	for i, k := range c.keys {
+		if k != key {
+			continue
+		}
+		if !c.used[i] {
+			c.used[i] = true
+			return c.interactions[i]
+		}
+		last = i
+	}
+	if last >= 0 {
+		return c.interactions[last]
+	}
+	return nil
+}
+
+func (c *Cassette) record(req *http.Request, body []byte) (*http.Response, error) {
+	rt := c.opts.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	if body != nil {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	it := &Interaction{
+		Request: CassetteRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: c.scrubHeader(req.Header),
+			Body:   c.scrubBody(body),
+		},
+		Response: CassetteResponse{
+			Status: resp.StatusCode,
+			Header: c.scrubHeader(resp.Header),
+			Body:   c.scrubBody(respBody),
+		},
+	}
+	c.mu.Lock()
+	c.add(it)
+	c.mu.Unlock()
+	return resp, nil
+}
+
+func (c *Cassette) add(it *Interaction) {
+	u, err := url.Parse(it.Request.URL)
+	if err != nil {
+		u = &url.URL{Path: it.Request.URL}
+	}
+	c.interactions = append(c.interactions, it)
+	c.keys = append(c.keys, c.matchKey(it.Request.Method, u, it.Request.Body))
+	c.used = append(c.used, false)
+}
+
+// Save 将录制结果写入文件（回放模式下为空操作）。
+func (c *Cassette) Save() error {
+	if c.opts.Mode != ModeRecord {
+		return nil
+	}
+	c.mu.Lock()
+	data, err := json.MarshalIndent(cassetteFile{Interactions: c.interactions}, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(c.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(c.path, append(data, '\n'), 0o644)
+}
+
+// Interactions 返回当前所有记录（录制模式为已录制的，回放模式为已加载的）。
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]Interaction, len(c.interactions))
+	for i, it := range c.interactions {
+		out[i] = *it
+	}
+	return out
+}
+
+// Unmatched 返回回放时未命中的请求（"METHOD URL"）。
+func (c *Cassette) Unmatched() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.unmatched...)
+}
+
+// Unused 返回回放时从未被命中的记录。
+func (c *Cassette) Unused() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []Interaction
+	for i, it := range c.interactions {
+		if !c.used[i] {
+			out = append(out, *it)
+		}
+	}
+	return out
+}
+
+// matchKey 生成匹配键：method + host + path + 规范化查询参数 + 规范化 JSON 请求体。
+func (c *Cassette) matchKey(method string, u *url.URL, body string) string {
+	q := u.Query()
+	for k := range q {
+		if c.ignoreQuery[strings.ToLower(k)] {
+			q.Del(k)
+		}
+	}
+	return strings.ToUpper(method) + " " + u.Host + u.Path + "?" + q.Encode() + "\n" + c.normalizeBody(body)
+}
+
+func (c *Cassette) normalizeBody(body string) string {
+	if body == "" {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return body
+	}
+	v = walkJSON(v, func(key string, val any) (any, bool) {
+		return nil, !c.ignoreFields[strings.ToLower(key)]
+	})
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func (c *Cassette) scrubBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	v = walkJSON(v, func(key string, val any) (any, bool) {
+		if !c.scrubFields[strings.ToLower(key)] {
+			return val, true
+		}
+		switch t := val.(type) {
+		case string:
+			return Redacted, true
+		case []any:
+			for i, item := range t {
+				if _, ok := item.(string); ok {
+					t[i] = Redacted
+				}
+			}
+		}
+		return val, true
+	})
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func (c *Cassette) scrubHeader(h http.Header) http.Header {
+	out := h.Clone()
+	for k := range out {
+		if c.scrubHeaders[strings.ToLower(k)] {
+			out[k] = []string{Redacted}
+		}
+	}
+	return out
+}
+
+// walkJSON 递归处理对象字段：fn 返回 (新值, 是否保留)。
+func walkJSON(v any, fn func(key string, val any) (any, bool)) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			nv, keep := fn(k, val)
+			if !keep {
+				delete(t, k)
+				continue
+			}
+			if nv != nil {
+				val = nv
+			}
+			t[k] = walkJSON(val, fn)
+		}
+	case []any:
+		for i := range t {
+			t[i] = walkJSON(t[i], fn)
+		}
+	}
+	return v
+}
+
+func (r CassetteResponse) toHTTP(req *http.Request) *http.Response {
+	h := r.Header.Clone()
+	if h == nil {
+		h = http.Header{}
+	}
+	h.Del("Content-Encoding")
+	h.Set("Content-Length", strconv.Itoa(len(r.Body)))
+	return &http.Response{
+		Status:        strconv.Itoa(r.Status) + " " + http.StatusText(r.Status),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        h,
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+func lowerSet(lists ...[]string) map[string]bool {
+	out := map[string]bool{}
+	for _, l := range lists {
+		for _, v := range l {
+			out[strings.ToLower(v)] = true
+		}
+	}
+	return out
+}
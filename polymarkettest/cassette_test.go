@@ -0,0 +1,134 @@
+// cassette_test.go 模块
+package polymarkettest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pm "github.com/dcsunny/polymarket-sdk"
+)
+
+func TestCassetteRecordReplay(t *testing.T) {
+	srv := NewServer(Options{})
+	defer srv.Close()
+	srv.SetMarket(MarketSpec{AssetID: testAsset, Market: testMarket, TickSize: 0.01})
+	srv.SetBook(testAsset, []Level{{Price: 0.45, Size: 100}}, []Level{{Price: 0.55, Size: 100}})
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec, err := NewCassette(path, CassetteOptions{Mode: ModeRecord})
+	if err != nil {
+		t.Fatalf("new recorder: %v", err)
+	}
+	cfg := srv.Config()
+	cfg.HTTPTransport = rec
+	sdk, err := pm.New(cfg)
+	if err != nil {
+		t.Fatalf("new sdk: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := sdk.CLOB.GetOrderBook(ctx, testAsset); err != nil {
+		t.Fatalf("record book: %v", err)
+	}
+	if _, err := sdk.CLOB.GetAPIKeys(ctx); err != nil {
+		t.Fatalf("record api keys: %v", err)
+	}
+	if resp := buyOrder(t, sdk); !resp.Success {
+		t.Fatalf("record order: %+v", resp)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read cassette: %v", err)
+	}
+	for _, secret := range []string{cfg.APIKey, cfg.APISecret, cfg.Passphrase} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette leaks credential %q", secret)
+		}
+	}
+	for _, it := range rec.Interactions() {
+		for k, v := range it.Request.Header {
+			// POLY_ADDRESS 为公开地址，不属于凭证。
+			name := strings.ToUpper(k)
+			if strings.HasPrefix(name, "POLY_") && name != "POLY_ADDRESS" && (len(v) != 1 || v[0] != Redacted) {
+				t.Errorf("header %s not scrubbed: %v", k, v)
+			}
+		}
+	}
+	if !strings.Contains(string(data), Redacted) {
+		t.Fatalf("cassette has no redacted values:\n%s", data)
+	}
+
+	// 回放时上游已关闭：所有响应必须来自 cassette。
+	srv.Close()
+	play, err := NewCassette(path, CassetteOptions{Strict: true})
+	if err != nil {
+		t.Fatalf("load cassette: %v", err)
+	}
+	cfg.HTTPTransport = play
+	sdk, err = pm.New(cfg)
+	if err != nil {
+		t.Fatalf("new sdk: %v", err)
+	}
+	book, err := sdk.CLOB.GetOrderBook(ctx, testAsset)
+	if err != nil || book.AssetID != testAsset {
+		t.Fatalf("replay book: %+v, err=%v", book, err)
+	}
+	// 重新签名的订单 salt / signature 与录制时不同，仍应命中。
+	if resp := buyOrder(t, sdk); !resp.Success {
+		t.Fatalf("replay order: %+v", resp)
+	}
+
+	// L2 头（时间戳、签名）与录制时不同，仍应命中。
+	req, _ := http.NewRequest(http.MethodGet, cfg.CLOBBaseURL+pm.EndpointGetAPIKeys, nil)
+	req.Header.Set("POLY_ADDRESS", cfg.Address)
+	req.Header.Set("POLY_TIMESTAMP", "1")
+	req.Header.Set("POLY_SIGNATURE", "different-signature")
+	resp, err := play.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("replay with new L2 headers: resp=%v err=%v", resp, err)
+	}
+	_ = resp.Body.Close()
+
+	if got := play.Unmatched(); len(got) != 0 {
+		t.Fatalf("unexpected misses: %v", got)
+	}
+	if got := play.Unused(); len(got) != 0 {
+		t.Fatalf("unused interactions: %v", got)
+	}
+
+	_, err = sdk.CLOB.GetOrderBook(ctx, "999")
+	if !errors.Is(err, ErrCassetteMiss) {
+		t.Fatalf("strict miss: err=%v, want ErrCassetteMiss", err)
+	}
+	if got := play.Unmatched(); len(got) != 1 || !strings.Contains(got[0], "999") {
+		t.Fatalf("unmatched = %v", got)
+	}
+}
+
+func TestCassetteNonStrictMiss(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.json")
+	if err := os.WriteFile(path, []byte(`{"interactions":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewCassette(path, CassetteOptions{})
+	if err != nil {
+		t.Fatalf("load cassette: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "http://clob.invalid/book?token_id=1", nil)
+	resp, err := c.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("round trip: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", resp.StatusCode)
+	}
+}
@@ -0,0 +1,324 @@
+// clob.go 模块
+package polymarkettest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	pm "github.com/dcsunny/polymarket-sdk"
+	"github.com/dcsunny/polymarket-sdk/internal/auth"
+)
+
+func (s *Server) clobHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, "OK")
+	})
+	mux.HandleFunc("GET "+pm.EndpointTime, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, s.Now().Unix())
+	})
+
+	// 公开市场数据
+	mux.HandleFunc("GET "+pm.EndpointGetOrderBook, s.withAsset(func(w http.ResponseWriter, _ *http.Request, book *pm.OrderBookSummary) {
+		writeJSON(w, http.StatusOK, book)
+	}))
+	mux.HandleFunc("POST "+pm.EndpointGetOrderBooks, func(w http.ResponseWriter, r *http.Request) {
+		var params []pm.BookParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid payload")
+			return
+		}
+		out := make([]*pm.OrderBookSummary, 0, len(params))
+		for _, p := range params {
+			if book := s.Book(p.TokenID); book != nil {
+				out = append(out, book)
+			}
+		}
+		writeJSON(w, http.StatusOK, out)
+	})
+	mux.HandleFunc("GET "+pm.EndpointGetMidpoint, s.withAsset(func(w http.ResponseWriter, _ *http.Request, book *pm.OrderBookSummary) {
+		bid, ask := bestPrices(book)
+		writeJSON(w, http.StatusOK, map[string]string{"mid": fmtNum(roundPrice((bid + ask) / 2))})
+	}))
+	mux.HandleFunc("GET "+pm.EndpointGetPrice, s.withAsset(func(w http.ResponseWriter, r *http.Request, book *pm.OrderBookSummary) {
+		bid, ask := bestPrices(book)
+		price := bid
+		if strings.EqualFold(r.URL.Query().Get("side"), pm.SideSell) {
+			price = ask
+		}
+		writeJSON(w, http.StatusOK, pm.PriceResponse{Price: fmtNum(price)})
+	}))
+	mux.HandleFunc("GET "+pm.EndpointGetTickSize, s.withSpec(func(w http.ResponseWriter, spec MarketSpec) {
+		writeJSON(w, http.StatusOK, pm.TickSizeResponse{MinimumTickSize: spec.TickSize})
+	}))
+	mux.HandleFunc("GET "+pm.EndpointGetNegRisk, s.withSpec(func(w http.ResponseWriter, spec MarketSpec) {
+		writeJSON(w, http.StatusOK, pm.NegRiskResponse{NegRisk: spec.NegRisk})
+	}))
+	mux.HandleFunc("GET "+pm.EndpointGetFeeRate, s.withSpec(func(w http.ResponseWriter, spec MarketSpec) {
+		writeJSON(w, http.StatusOK, pm.FeeRateResponse{BaseFee: spec.FeeRateBps})
+	}))
+
+	// API Key（L1）
+	l1Creds := func(w http.ResponseWriter, r *http.Request) {
+		if !strings.EqualFold(r.Header.Get("POLY_ADDRESS"), s.address) || r.Header.Get("POLY_SIGNATURE") == "" {
+			writeError(w, http.StatusUnauthorized, "Unauthorized/Invalid api key")
+			return
+		}
+		writeJSON(w, http.StatusOK, pm.APICredentials{APIKey: s.opts.APIKey, Secret: s.opts.APISecret, Passphrase: s.opts.Passphrase})
+	}
+	mux.HandleFunc("GET "+pm.EndpointDeriveAPIKey, l1Creds)
+	mux.HandleFunc("POST "+pm.EndpointCreateAPIKey, l1Creds)
+	mux.HandleFunc("GET "+pm.EndpointGetAPIKeys, s.l2(func(w http.ResponseWriter, _ *http.Request, _ []byte) {
+		writeJSON(w, http.StatusOK, pm.APIKeysResponse{APIKeys: []string{s.opts.APIKey}})
+	}))
+
+	// 订单（L2）
+	mux.HandleFunc("POST "+pm.EndpointPostOrder, s.l2(func(w http.ResponseWriter, _ *http.Request, body []byte) {
+		var p pm.PostOrder
+		if err := json.Unmarshal(body, &p); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid order payload")
+			return
+		}
+		resp := s.placeOrder(&p)
+		if !resp.Success {
+			writeJSON(w, http.StatusBadRequest, resp)
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}))
+	mux.HandleFunc("POST "+pm.EndpointPostOrders, s.l2(func(w http.ResponseWriter, _ *http.Request, body []byte) {
+		var ps []*pm.PostOrder
+		if err := json.Unmarshal(body, &ps); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid orders payload")
+			return
+		}
+		out := make([]pm.OrderResponse, 0, len(ps))
+		for _, p := range ps {
+			out = append(out, s.placeOrder(p))
+		}
+		writeJSON(w, http.StatusOK, out)
+	}))
+	mux.HandleFunc("DELETE "+pm.EndpointCancelOrder, s.l2(func(w http.ResponseWriter, _ *http.Request, body []byte) {
+		var req struct {
+			OrderID string `json:"orderID"`
+		}
+		_ = json.Unmarshal(body, &req)
+		s.writeCancel(w, nil, []string{req.OrderID})
+	}))
+	mux.HandleFunc("DELETE "+pm.EndpointCancelOrders, s.l2(func(w http.ResponseWriter, _ *http.Request, body []byte) {
+		var ids []string
+		_ = json.Unmarshal(body, &ids)
+		s.writeCancel(w, nil, ids)
+	}))
+	mux.HandleFunc("DELETE "+pm.EndpointCancelAll, s.l2(func(w http.ResponseWriter, _ *http.Request, _ []byte) {
+		s.writeCancel(w, func(*order) bool { return true }, nil)
+	}))
+	mux.HandleFunc("DELETE "+pm.EndpointCancelMarketOrders, s.l2(func(w http.ResponseWriter, _ *http.Request, body []byte) {
+		var req pm.CancelMarketOrdersRequest
+		_ = json.Unmarshal(body, &req)
+		s.writeCancel(w, func(o *order) bool {
+			return (req.Market == "" || o.market == req.Market) && (req.AssetID == "" || o.assetID == req.AssetID)
+		}, nil)
+	}))
+	mux.HandleFunc("GET "+pm.EndpointGetOpenOrders, s.l2(func(w http.ResponseWriter, r *http.Request, _ []byte) {
+		q := r.URL.Query()
+		var out []*pm.OpenOrder
+		for _, o := range s.Orders() {
+			if o.Status != orderStatusLive {
+				continue
+			}
+			if (q.Get("id") != "" && o.ID != q.Get("id")) ||
+				(q.Get("market") != "" && o.Market != q.Get("market")) ||
+				(q.Get("asset_id") != "" && o.AssetID != q.Get("asset_id")) {
+				continue
+			}
+			out = append(out, o)
+		}
+		writePage(w, r, s.currentPageSize(), out)
+	}))
+	mux.HandleFunc("GET "+pm.EndpointGetOrderPrefix+"{id}", s.l2(func(w http.ResponseWriter, r *http.Request, _ []byte) {
+		for _, o := range s.Orders() {
+			if o.ID == r.PathValue("id") {
+				writeJSON(w, http.StatusOK, o)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "order not found")
+	}))
+	mux.HandleFunc("GET "+pm.EndpointGetTrades, s.l2(func(w http.ResponseWriter, r *http.Request, _ []byte) {
+		q := r.URL.Query()
+		var out []*pm.Trade
+		for _, t := range s.Trades() {
+			if (q.Get("id") != "" && t.ID != q.Get("id")) ||
+				(q.Get("market") != "" && t.Market != q.Get("market")) {
+				continue
+			}
+			out = append(out, t)
+		}
+		writePage(w, r, s.currentPageSize(), out)
+	}))
+	return mux
+}
+
+func (s *Server) placeOrder(p *pm.PostOrder) pm.OrderResponse {
+	res := s.exchange.place(p)
+	s.hub.publishUser(res.events[:min(1, len(res.events))])
+	s.hub.publishUserTrades(res.fills)
+	if len(res.events) > 1 {
+		s.hub.publishUser(res.events[1:])
+	}
+	if res.book != nil {
+		s.hub.publishMarket(res.asset, *res.book)
+	}
+	return res.resp
+}
+
+func (s *Server) writeCancel(w http.ResponseWriter, match func(*order) bool, ids []string) {
+	resp, events, touched := s.exchange.cancel(match, ids)
+	s.hub.publishUser(events)
+	for _, id := range touched {
+		if book := s.Book(id); book != nil {
+			s.hub.publishMarket(id, *book)
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// l2 校验 L2 HMAC 头后调用 next；body 为原始请求体。
+func (s *Server) l2(next func(w http.ResponseWriter, r *http.Request, body []byte)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if msg := s.verifyL2(r, body); msg != "" {
+			writeError(w, http.StatusUnauthorized, msg)
+			return
+		}
+		next(w, r, body)
+	}
+}
+
+func (s *Server) verifyL2(r *http.Request, body []byte) string {
+	h := r.Header
+	for _, k := range []string{"POLY_ADDRESS", "POLY_SIGNATURE", "POLY_TIMESTAMP", "POLY_API_KEY", "POLY_PASSPHRASE"} {
+		if h.Get(k) == "" {
+			return "Unauthorized/Invalid api key: missing " + k
+		}
+	}
+	if s.opts.DisableAuth {
+		return ""
+	}
+	if h.Get("POLY_API_KEY") != s.opts.APIKey || h.Get("POLY_PASSPHRASE") != s.opts.Passphrase {
+		return "Unauthorized/Invalid api key"
+	}
+	ts := h.Get("POLY_TIMESTAMP")
+	if s.opts.AuthSkew > 0 {
+		sec, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return "Unauthorized/Invalid timestamp"
+		}
+		if d := s.Now().Sub(time.Unix(sec, 0)); d > s.opts.AuthSkew || d < -s.opts.AuthSkew {
+			return "Unauthorized/Invalid timestamp: clock skew " + d.String()
+		}
+	}
+	want := auth.L2Signature(s.opts.APISecret, ts, r.Method, r.URL.Path, string(body))
+	if !equalSignature(want, h.Get("POLY_SIGNATURE")) {
+		return "Unauthorized/Invalid signature"
+	}
+	return ""
+}
+
+func equalSignature(a, b string) bool {
+	da, err1 := base64.URLEncoding.DecodeString(a)
+	db, err2 := base64.URLEncoding.DecodeString(b)
+	if err1 != nil || err2 != nil {
+		return a == b
+	}
+	return string(da) == string(db)
+}
+
+func (s *Server) withAsset(next func(w http.ResponseWriter, r *http.Request, book *pm.OrderBookSummary)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		book := s.Book(r.URL.Query().Get("token_id"))
+		if book == nil {
+			writeError(w, http.StatusNotFound, "No orderbook exists for the requested token id")
+			return
+		}
+		next(w, r, book)
+	}
+}
+
+func (s *Server) withSpec(next func(w http.ResponseWriter, spec MarketSpec)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		e := s.exchange
+		e.mu.Lock()
+		a, ok := e.assets[r.URL.Query().Get("token_id")]
+		var spec MarketSpec
+		if ok {
+			spec = a.spec
+		}
+		e.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "market not found")
+			return
+		}
+		next(w, spec)
+	}
+}
+
+func bestPrices(book *pm.OrderBookSummary) (bid, ask float64) {
+	if n := len(book.Bids); n > 0 {
+		bid, _ = strconv.ParseFloat(book.Bids[n-1].Price, 64)
+	}
+	if n := len(book.Asks); n > 0 {
+		ask, _ = strconv.ParseFloat(book.Asks[n-1].Price, 64)
+	}
+	return bid, ask
+}
+
+// SetPageSize 设置 CLOB 游标分页接口的每页条数；0 表示不分页（单页返回全部）。
+func (s *Server) SetPageSize(n int) {
+	s.mu.Lock()
+	s.pageSize = n
+	s.mu.Unlock()
+}
+
+func (s *Server) currentPageSize() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pageSize
+}
+
+// writePage 以 CLOB 游标分页格式输出（游标为 base64 的偏移量，与官方一致）。
+func writePage[T any](w http.ResponseWriter, r *http.Request, size int, items []T) {
+	offset := 0
+	if c := r.URL.Query().Get("next_cursor"); c != "" {
+		if raw, err := base64.StdEncoding.DecodeString(c); err == nil {
+			offset, _ = strconv.Atoi(string(raw))
+		}
+	}
+	if offset > len(items) || offset < 0 {
+		offset = len(items)
+	}
+	end := len(items)
+	if size > 0 && offset+size < end {
+		end = offset + size
+	}
+	next := pm.EndCursor
+	if end < len(items) {
+		next = base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(end)))
+	}
+	page := items[offset:end]
+	if page == nil {
+		page = []T{}
+	}
+	writeJSON(w, http.StatusOK, pm.PaginatedResponse[T]{
+		Limit:      len(page),
+		Count:      len(page),
+		NextCursor: next,
+		Data:       page,
+	})
+}
@@ -0,0 +1,196 @@
+// data.go 模块
+package polymarkettest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	pm "github.com/dcsunny/polymarket-sdk"
+)
+
+type dataStore struct {
+	mu          sync.Mutex
+	positions   []*pm.Position
+	activity    []*pm.Activity
+	trades      []*pm.DataTrade
+	holders     map[string][]*pm.Holder
+	leaderboard []*pm.LeaderboardEntry
+}
+
+func newDataStore() *dataStore {
+	return &dataStore{holders: make(map[string][]*pm.Holder)}
+}
+
+// AddPosition 向 Data API 假服务添加一条持仓（按 ProxyWallet 归属用户）。
+func (s *Server) AddPosition(p pm.Position) {
+	db := s.dataDB
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.positions = append(db.positions, &p)
+}
+
+// AddActivity 向 Data API 假服务添加一条用户活动。
+func (s *Server) AddActivity(a pm.Activity) {
+	db := s.dataDB
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.activity = append(db.activity, &a)
+}
+
+// AddDataTrade 向 Data API 假服务添加一条成交。
+func (s *Server) AddDataTrade(t pm.DataTrade) {
+	db := s.dataDB
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.trades = append(db.trades, &t)
+}
+
+// AddHolder 为市场（condition ID）添加一个持有人；按 Holder.Asset 分组返回。
+func (s *Server) AddHolder(market string, h pm.Holder) {
+	db := s.dataDB
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.holders[market] = append(db.holders[market], &h)
+}
+
+// SetLeaderboard 替换排行榜。
+func (s *Server) SetLeaderboard(entries ...pm.LeaderboardEntry) {
+	db := s.dataDB
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.leaderboard = db.leaderboard[:0]
+	for i := range entries {
+		db.leaderboard = append(db.leaderboard, &entries[i])
+	}
+}
+
+func (s *Server) dataHandler() http.Handler {
+	mux := http.NewServeMux()
+	db := s.dataDB
+
+	mux.HandleFunc("GET /positions", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("user") == "" {
+			writeError(w, http.StatusBadRequest, "user is required")
+			return
+		}
+		markets := splitList(q.Get("market"))
+		db.mu.Lock()
+		var out []*pm.Position
+		for _, p := range db.positions {
+			if !strings.EqualFold(p.ProxyWallet, q.Get("user")) {
+				continue
+			}
+			if len(markets) > 0 && !contains(markets, p.ConditionID) {
+				continue
+			}
+			out = append(out, p)
+		}
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, window(out, q.Get("limit"), q.Get("offset")))
+	})
+
+	mux.HandleFunc("GET /activity", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("user") == "" {
+			writeError(w, http.StatusBadRequest, "user is required")
+			return
+		}
+		types := splitList(q.Get("type"))
+		db.mu.Lock()
+		var out []*pm.Activity
+		for _, a := range db.activity {
+			if !strings.EqualFold(a.ProxyWallet, q.Get("user")) {
+				continue
+			}
+			if len(types) > 0 && !contains(types, a.Type) {
+				continue
+			}
+			out = append(out, a)
+		}
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, window(out, q.Get("limit"), q.Get("offset")))
+	})
+
+	mux.HandleFunc("GET /trades", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		markets := splitList(q.Get("market"))
+		db.mu.Lock()
+		var out []*pm.DataTrade
+		for _, t := range db.trades {
+			if q.Get("user") != "" && !strings.EqualFold(t.ProxyWallet, q.Get("user")) {
+				continue
+			}
+			if len(markets) > 0 && !contains(markets, t.ConditionID) {
+				continue
+			}
+			if q.Get("side") != "" && t.Side != q.Get("side") {
+				continue
+			}
+			out = append(out, t)
+		}
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, window(out, q.Get("limit"), q.Get("offset")))
+	})
+
+	mux.HandleFunc("GET /holders", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		markets := splitList(q.Get("market"))
+		if len(markets) == 0 {
+			writeError(w, http.StatusBadRequest, "market is required")
+			return
+		}
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		db.mu.Lock()
+		out := []*pm.MarketHolders{}
+		byToken := make(map[string]*pm.MarketHolders)
+		for _, m := range markets {
+			for _, h := range db.holders[m] {
+				group := byToken[h.Asset]
+				if group == nil {
+					group = &pm.MarketHolders{Token: h.Asset}
+					byToken[h.Asset] = group
+					out = append(out, group)
+				}
+				if limit <= 0 || len(group.Holders) < limit {
+					group.Holders = append(group.Holders, h)
+				}
+			}
+		}
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, out)
+	})
+
+	mux.HandleFunc("GET /value", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		user := q.Get("user")
+		markets := splitList(q.Get("market"))
+		db.mu.Lock()
+		total := 0.0
+		for _, p := range db.positions {
+			if strings.EqualFold(p.ProxyWallet, user) && (len(markets) == 0 || contains(markets, p.ConditionID)) {
+				total += p.CurrentValue
+			}
+		}
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, []pm.PortfolioValue{{User: user, Value: total}})
+	})
+
+	mux.HandleFunc("GET /v1/leaderboard", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		db.mu.Lock()
+		var out []*pm.LeaderboardEntry
+		for _, e := range db.leaderboard {
+			if q.Get("user") != "" && !strings.EqualFold(e.ProxyWallet, q.Get("user")) {
+				continue
+			}
+			out = append(out, e)
+		}
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, window(out, q.Get("limit"), q.Get("offset")))
+	})
+
+	return mux
+}
@@ -0,0 +1,505 @@
+// exchange.go 模块
+package polymarkettest
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	pm "github.com/dcsunny/polymarket-sdk"
+)
+
+// Level 是订单簿中的一个价位。
+type Level struct {
+	Price float64
+	Size  float64
+}
+
+// MarketSpec 描述一个 token 的市场参数。
+type MarketSpec struct {
+	AssetID string
+	// Market 为 condition id；为空时使用 AssetID。
+	Market       string
+	Outcome      string
+	TickSize     float64
+	MinOrderSize float64
+	NegRisk      bool
+	FeeRateBps   int
+	// Closed 为 true 时拒绝新订单（返回 market closed 错误）。
+	Closed bool
+}
+
+type asset struct {
+	spec      MarketSpec
+	bids      []Level // 外部流动性，价格降序
+	asks      []Level // 外部流动性，价格升序
+	lastTrade float64
+	lastSide  string
+}
+
+type order struct {
+	id        string
+	assetID   string
+	market    string
+	side      string
+	price     float64
+	original  float64
+	matched   float64
+	orderType pm.OrderType
+	status    string
+	createdAt time.Time
+}
+
+func (o *order) remaining() float64 {
+	return o.original - o.matched
+}
+
+type exchange struct {
+	s *Server
+
+	mu     sync.Mutex
+	assets map[string]*asset
+	orders map[string]*order
+	trades []*pm.Trade
+	seq    int
+}
+
+func newExchange(s *Server) *exchange {
+	return &exchange{
+		s:      s,
+		assets: make(map[string]*asset),
+		orders: make(map[string]*order),
+	}
+}
+
+// SetMarket 注册或更新 token 的市场参数。
+func (s *Server) SetMarket(spec MarketSpec) {
+	e := s.exchange
+	e.mu.Lock()
+	a := e.assetLocked(spec.AssetID)
+	if spec.Market == "" {
+		spec.Market = spec.AssetID
+	}
+	if spec.TickSize == 0 {
+		spec.TickSize = 0.01
+	}
+	a.spec = spec
+	e.mu.Unlock()
+}
+
+// SetBook 替换 token 的外部流动性（不包含测试账户自己的挂单），并向 market 频道推送快照。
+func (s *Server) SetBook(assetID string, bids, asks []Level) {
+	e := s.exchange
+	e.mu.Lock()
+	a := e.assetLocked(assetID)
+	a.bids = append([]Level(nil), bids...)
+	a.asks = append([]Level(nil), asks...)
+	sortLevels(a.bids, true)
+	sortLevels(a.asks, false)
+	book := e.bookLocked(a)
+	e.mu.Unlock()
+
+	s.hub.publishMarket(assetID, book)
+}
+
+// Book 返回 token 当前的订单簿快照（外部流动性 + 测试账户挂单）。
+func (s *Server) Book(assetID string) *pm.OrderBookSummary {
+	e := s.exchange
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	a, ok := e.assets[assetID]
+	if !ok {
+		return nil
+	}
+	book := e.bookLocked(a)
+	return &book
+}
+
+// Orders 返回测试账户所有订单（含已成交/已撤销）。
+func (s *Server) Orders() []*pm.OpenOrder {
+	e := s.exchange
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := make([]*pm.OpenOrder, 0, len(e.orders))
+	for _, o := range e.sortedOrdersLocked() {
+		out = append(out, e.openOrderLocked(o))
+	}
+	return out
+}
+
+// Trades 返回测试账户的成交记录。
+func (s *Server) Trades() []*pm.Trade {
+	e := s.exchange
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]*pm.Trade(nil), e.trades...)
+}
+
+// ExecuteTaker 模拟外部 taker 以 price 吃掉测试账户的挂单（side 为 taker 方向），返回产生的成交。
+// 用于测试 user 频道的成交推送与持仓跟踪。
+func (s *Server) ExecuteTaker(assetID, side string, price, size float64) []*pm.Trade {
+	e := s.exchange
+	e.mu.Lock()
+	var fills []*pm.Trade
+	var updated []*order
+	for _, o := range e.sortedOrdersLocked() {
+		if size <= 0 {
+			break
+		}
+		if o.assetID != assetID || o.status != orderStatusLive || o.side == side {
+			continue
+		}
+		if (side == pm.SideBuy && o.price > price) || (side == pm.SideSell && o.price < price) {
+			continue
+		}
+		qty := math.Min(size, o.remaining())
+		size -= qty
+		o.matched += qty
+		if o.remaining() <= 1e-9 {
+			o.status = orderStatusMatched
+		}
+		fills = append(fills, e.recordTradeLocked(o, o.price, qty, pm.SideBuy == o.side, "MAKER"))
+		updated = append(updated, o)
+	}
+	events := e.orderEventsLocked(updated, "UPDATE")
+	var book pm.OrderBookSummary
+	if a, ok := e.assets[assetID]; ok {
+		book = e.bookLocked(a)
+	}
+	e.mu.Unlock()
+
+	s.hub.publishUserTrades(fills)
+	s.hub.publishUser(events)
+	if len(fills) > 0 {
+		s.hub.publishMarket(assetID, book)
+	}
+	return fills
+}
+
+const (
+	orderStatusLive      = "LIVE"
+	orderStatusMatched   = "MATCHED"
+	orderStatusCanceled  = "CANCELED"
+	orderStatusUnmatched = "UNMATCHED"
+)
+
+type placeResult struct {
+	resp   pm.OrderResponse
+	fills  []*pm.Trade
+	events []any
+	asset  string
+	book   *pm.OrderBookSummary
+}
+
+// place 撮合一个测试账户的订单（对手方为外部流动性）。
+func (e *exchange) place(p *pm.PostOrder) placeResult {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ao := p.Order
+	side, price, size, err := decodeOrder(ao)
+	if err != nil {
+		return placeResult{resp: pm.OrderResponse{ErrorMsg: err.Error()}}
+	}
+	a, ok := e.assets[ao.TokenID]
+	if !ok {
+		return placeResult{resp: pm.OrderResponse{ErrorMsg: "No orderbook exists for the requested token id"}}
+	}
+	if a.spec.Closed {
+		return placeResult{resp: pm.OrderResponse{ErrorMsg: "market is closed"}}
+	}
+	if tick := a.spec.TickSize; tick > 0 && math.Abs(price/tick-math.Round(price/tick)) > 1e-6 {
+		return placeResult{resp: pm.OrderResponse{ErrorMsg: fmt.Sprintf("order is invalid. Price (%s) breaks minimum tick size rule: %s", fmtNum(price), fmtNum(tick))}}
+	}
+	if a.spec.MinOrderSize > 0 && size < a.spec.MinOrderSize {
+		return placeResult{resp: pm.OrderResponse{ErrorMsg: "order is invalid. Size lower than the minimum"}}
+	}
+
+	opposite := &a.asks
+	if side == pm.SideSell {
+		opposite = &a.bids
+	}
+	crosses := func(lvl Level) bool {
+		if side == pm.SideBuy {
+			return lvl.Price <= price+1e-9
+		}
+		return lvl.Price >= price-1e-9
+	}
+	available := 0.0
+	for _, lvl := range *opposite {
+		if !crosses(lvl) {
+			break
+		}
+		available += lvl.Size
+	}
+	if p.PostOnly && available > 0 {
+		return placeResult{resp: pm.OrderResponse{ErrorMsg: "invalid post-only order: order crosses book"}}
+	}
+	if p.OrderType == pm.OrderTypeFOK && available+1e-9 < size {
+		return placeResult{resp: pm.OrderResponse{ErrorMsg: "order couldn't be fully filled. FOK orders are fully filled or killed."}}
+	}
+
+	e.seq++
+	o := &order{
+		id:        fmt.Sprintf("0x%064x", e.seq),
+		assetID:   ao.TokenID,
+		market:    a.spec.Market,
+		side:      side,
+		price:     price,
+		original:  size,
+		orderType: p.OrderType,
+		status:    orderStatusLive,
+		createdAt: e.s.Now(),
+	}
+	e.orders[o.id] = o
+
+	res := placeResult{asset: o.assetID}
+	res.events = e.orderEventsLocked([]*order{o}, "PLACEMENT")
+
+	levels := *opposite
+	for len(levels) > 0 && o.remaining() > 1e-9 && crosses(levels[0]) {
+		qty := math.Min(o.remaining(), levels[0].Size)
+		o.matched += qty
+		levels[0].Size -= qty
+		res.fills = append(res.fills, e.recordTradeLocked(o, levels[0].Price, qty, side == pm.SideBuy, "TAKER"))
+		if levels[0].Size <= 1e-9 {
+			levels = levels[1:]
+		}
+	}
+	*opposite = levels
+
+	switch {
+	case o.remaining() <= 1e-9:
+		o.status = orderStatusMatched
+	case p.OrderType == pm.OrderTypeFAK || p.OrderType == pm.OrderTypeFOK:
+		if o.matched == 0 {
+			o.status = orderStatusUnmatched
+		} else {
+			o.status = orderStatusMatched
+		}
+	}
+	if len(res.fills) > 0 {
+		res.events = append(res.events, e.orderEventsLocked([]*order{o}, "UPDATE")...)
+	}
+
+	res.resp = pm.OrderResponse{
+		Success: true,
+		OrderID: o.id,
+		Status:  map[string]string{orderStatusLive: "live", orderStatusMatched: "matched", orderStatusUnmatched: "unmatched"}[o.status],
+	}
+	book := e.bookLocked(a)
+	res.book = &book
+	return res
+}
+
+// cancel 撤销满足 match 的所有挂单。
+func (e *exchange) cancel(match func(o *order) bool, ids []string) (*pm.CancelOrdersResponse, []any, []string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	resp := &pm.CancelOrdersResponse{Canceled: []string{}, NotCanceled: map[string]string{}}
+	var canceled []*order
+	for _, id := range ids {
+		o, ok := e.orders[id]
+		switch {
+		case !ok:
+			resp.NotCanceled[id] = "order not found"
+		case o.status != orderStatusLive:
+			resp.NotCanceled[id] = "order can't be canceled: already " + o.status
+		default:
+			canceled = append(canceled, o)
+		}
+	}
+	if match != nil {
+		for _, o := range e.sortedOrdersLocked() {
+			if o.status == orderStatusLive && match(o) {
+				canceled = append(canceled, o)
+			}
+		}
+	}
+	assets := map[string]bool{}
+	for _, o := range canceled {
+		o.status = orderStatusCanceled
+		resp.Canceled = append(resp.Canceled, o.id)
+		assets[o.assetID] = true
+	}
+	touched := make([]string, 0, len(assets))
+	for id := range assets {
+		touched = append(touched, id)
+	}
+	sort.Strings(touched)
+	return resp, e.orderEventsLocked(canceled, "CANCELLATION"), touched
+}
+
+func (e *exchange) assetLocked(id string) *asset {
+	a, ok := e.assets[id]
+	if !ok {
+		a = &asset{spec: MarketSpec{AssetID: id, Market: id, TickSize: 0.01}}
+		e.assets[id] = a
+	}
+	return a
+}
+
+func (e *exchange) sortedOrdersLocked() []*order {
+	out := make([]*order, 0, len(e.orders))
+	for _, o := range e.orders {
+		out = append(out, o)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].id < out[j].id })
+	return out
+}
+
+func (e *exchange) bookLocked(a *asset) pm.OrderBookSummary {
+	bids := append([]Level(nil), a.bids...)
+	asks := append([]Level(nil), a.asks...)
+	for _, o := range e.orders {
+		if o.assetID != a.spec.AssetID || o.status != orderStatusLive {
+			continue
+		}
+		if o.side == pm.SideBuy {
+			bids = addLevel(bids, o.price, o.remaining())
+		} else {
+			asks = addLevel(asks, o.price, o.remaining())
+		}
+	}
+	sortLevels(bids, true)
+	sortLevels(asks, false)
+
+	book := pm.OrderBookSummary{
+		Market:       a.spec.Market,
+		AssetID:      a.spec.AssetID,
+		Timestamp:    strconv.FormatInt(e.s.Now().UnixMilli(), 10),
+		TickSize:     fmtNum(a.spec.TickSize),
+		MinOrderSize: fmtNum(a.spec.MinOrderSize),
+		NegRisk:      a.spec.NegRisk,
+		Bids:         []pm.OrderSummary{},
+		Asks:         []pm.OrderSummary{},
+	}
+	if a.lastTrade > 0 {
+		book.LastTradePrice = fmtNum(a.lastTrade)
+	}
+	// 与官方接口一致：bids/asks 均按价格从差到优排列，最优价在末尾。
+	for i := len(bids) - 1; i >= 0; i-- {
+		book.Bids = append(book.Bids, pm.OrderSummary{Price: fmtNum(bids[i].Price), Size: fmtNum(bids[i].Size)})
+	}
+	for i := len(asks) - 1; i >= 0; i-- {
+		book.Asks = append(book.Asks, pm.OrderSummary{Price: fmtNum(asks[i].Price), Size: fmtNum(asks[i].Size)})
+	}
+	book.Hash = fmt.Sprintf("%x", e.seq)
+	return book
+}
+
+func (e *exchange) recordTradeLocked(o *order, price, size float64, buy bool, traderSide string) *pm.Trade {
+	side := pm.SideSell
+	if buy {
+		side = pm.SideBuy
+	}
+	if a, ok := e.assets[o.assetID]; ok {
+		a.lastTrade = price
+		a.lastSide = side
+	}
+	e.seq++
+	now := e.s.Now()
+	t := &pm.Trade{
+		ID:           fmt.Sprintf("trade-%d", e.seq),
+		TakerOrderID: o.id,
+		Market:       o.market,
+		AssetID:      o.assetID,
+		Side:         side,
+		Size:         fmtNum(size),
+		FeeRateBps:   "0",
+		Price:        fmtNum(price),
+		Status:       "MATCHED",
+		MatchTime:    strconv.FormatInt(now.Unix(), 10),
+		LastUpdate:   strconv.FormatInt(now.Unix(), 10),
+		Owner:        e.s.opts.APIKey,
+		MakerAddress: e.s.address,
+		Type:         traderSide,
+	}
+	e.trades = append(e.trades, t)
+	return t
+}
+
+func (e *exchange) openOrderLocked(o *order) *pm.OpenOrder {
+	return &pm.OpenOrder{
+		ID:           o.id,
+		Market:       o.market,
+		AssetID:      o.assetID,
+		Price:        fmtNum(o.price),
+		Size:         fmtNum(o.original),
+		Side:         o.side,
+		OrderType:    string(o.orderType),
+		Status:       o.status,
+		Owner:        e.s.opts.APIKey,
+		MakerAddress: e.s.address,
+		OriginalSize: fmtNum(o.original),
+		SizeMatched:  fmtNum(o.matched),
+		CreatedAt:    o.createdAt.Unix(),
+	}
+}
+
+func (e *exchange) orderEventsLocked(orders []*order, typ string) []any {
+	out := make([]any, 0, len(orders))
+	for _, o := range orders {
+		out = append(out, pm.WSSOrderEvent{
+			EventType:    "order",
+			ID:           o.id,
+			Market:       o.market,
+			AssetID:      o.assetID,
+			OrderOwner:   e.s.opts.APIKey,
+			Price:        fmtNum(o.price),
+			Side:         o.side,
+			OriginalSize: fmtNum(o.original),
+			SizeMatched:  fmtNum(o.matched),
+			Timestamp:    pm.FlexInt(e.s.Now().UnixMilli()),
+			Type:         typ,
+		})
+	}
+	return out
+}
+
+// decodeOrder 从签名订单金额反推方向、价格与数量（金额为 1e6 精度）。
+func decodeOrder(o pm.APIOrder) (side string, price, size float64, err error) {
+	maker, err1 := strconv.ParseFloat(o.MakerAmount, 64)
+	taker, err2 := strconv.ParseFloat(o.TakerAmount, 64)
+	if err1 != nil || err2 != nil || maker <= 0 || taker <= 0 {
+		return "", 0, 0, fmt.Errorf("invalid order amounts")
+	}
+	switch o.Side {
+	case pm.SideBuy:
+		return pm.SideBuy, roundPrice(maker / taker), taker / 1e6, nil
+	case pm.SideSell:
+		return pm.SideSell, roundPrice(taker / maker), maker / 1e6, nil
+	}
+	return "", 0, 0, fmt.Errorf("invalid order side")
+}
+
+func roundPrice(p float64) float64 {
+	return math.Round(p*1e6) / 1e6
+}
+
+func addLevel(levels []Level, price, size float64) []Level {
+	for i := range levels {
+		if math.Abs(levels[i].Price-price) < 1e-9 {
+			levels[i].Size += size
+			return levels
+		}
+	}
+	return append(levels, Level{Price: price, Size: size})
+}
+
+func sortLevels(levels []Level, desc bool) {
+	sort.SliceStable(levels, func(i, j int) bool {
+		if desc {
+			return levels[i].Price > levels[j].Price
+		}
+		return levels[i].Price < levels[j].Price
+	})
+}
+
+func fmtNum(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
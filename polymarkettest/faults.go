@@ -0,0 +1,133 @@
+// faults.go 模块
+package polymarkettest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// 故障注入作用的服务。
+const (
+	ServiceCLOB  = "clob"
+	ServiceGamma = "gamma"
+	ServiceData  = "data"
+)
+
+// Fault 描述一次 HTTP 故障注入。
+type Fault struct {
+	// Service 为 ServiceCLOB / ServiceGamma / ServiceData；为空匹配全部。
+	Service string
+	// Method 为空匹配全部方法。
+	Method string
+	// Path 精确匹配；以 `*` 结尾时按前缀匹配；为空匹配全部路径。
+	Path string
+
+	// Status 非 0 时直接返回该状态码与 Body（不再进入正常处理）。
+	Status int
+	// Body 响应体；为空时返回 {"error": http.StatusText(Status)}。
+	Body string
+	// Header 额外响应头（例如 Retry-After）。
+	Header http.Header
+	// Delay 处理前的延迟（可单独使用以模拟慢响应）。
+	Delay time.Duration
+	// DropConnection 直接断开 TCP 连接（模拟网络错误）。
+	DropConnection bool
+
+	// Times 生效次数；0 表示一直生效。
+	Times int
+}
+
+type faultState struct {
+	Fault
+	used int
+}
+
+// InjectFault 注入一条故障规则；多条规则按注入顺序匹配第一条。
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	s.faults = append(s.faults, &faultState{Fault: f})
+	s.mu.Unlock()
+}
+
+// ClearFaults 清除所有故障规则。
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	s.faults = nil
+	s.mu.Unlock()
+}
+
+// DisconnectWebSockets 断开当前所有 websocket 连接（market/user/RTDS），用于测试重连。
+func (s *Server) DisconnectWebSockets() {
+	s.hub.closeAll()
+}
+
+func (s *Server) applyFault(service string, w http.ResponseWriter, r *http.Request) bool {
+	s.mu.Lock()
+	var hit *Fault
+	for i, f := range s.faults {
+		if !f.matches(service, r) {
+			continue
+		}
+		f.used++
+		cp := f.Fault
+		hit = &cp
+		if f.Times > 0 && f.used >= f.Times {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+		break
+	}
+	s.mu.Unlock()
+	if hit == nil {
+		return false
+	}
+
+	if hit.Delay > 0 {
+		select {
+		case <-time.After(hit.Delay):
+		case <-r.Context().Done():
+			return true
+		}
+	}
+	if hit.DropConnection {
+		if hj, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hj.Hijack(); err == nil {
+				_ = conn.Close()
+				return true
+			}
+		}
+		panic(http.ErrAbortHandler)
+	}
+	if hit.Status == 0 {
+		return false
+	}
+	for k, vals := range hit.Header {
+		for _, v := range vals {
+			w.Header().Add(k, v)
+		}
+	}
+	if hit.Body == "" {
+		writeError(w, hit.Status, http.StatusText(hit.Status))
+		return true
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(hit.Status)
+	_, _ = w.Write([]byte(hit.Body))
+	return true
+}
+
+func (f *faultState) matches(service string, r *http.Request) bool {
+	if f.Service != "" && f.Service != service {
+		return false
+	}
+	if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+		return false
+	}
+	if f.Path == "" {
+		return true
+	}
+	if strings.HasSuffix(f.Path, "*") {
+		return strings.HasPrefix(r.URL.Path, strings.TrimSuffix(f.Path, "*"))
+	}
+	return r.URL.Path == f.Path
+}
@@ -0,0 +1,489 @@
+// gamma.go 模块
+package polymarkettest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	pm "github.com/dcsunny/polymarket-sdk"
+)
+
+type gammaStore struct {
+	mu      sync.Mutex
+	events  []*pm.Event
+	markets []*pm.Market
+	tags    []*pm.Tag
+	related map[string][]string
+	series  []*pm.Series
+	sports  []*pm.Sport
+	teams   []*pm.Team
+
+	comments []*pm.Comment
+	profiles map[string]*pm.PublicProfile
+}
+
+func newGammaStore() *gammaStore {
+	return &gammaStore{
+		related:  make(map[string][]string),
+		profiles: make(map[string]*pm.PublicProfile),
+	}
+}
+
+// AddEvent 向 Gamma 假服务添加一个事件；ID 为 0 时自动分配。
+func (s *Server) AddEvent(e pm.Event) {
+	db := s.gammaDB
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if e.ID == 0 {
+		if id, err := strconv.ParseInt(e.IDRaw, 10, 64); err == nil {
+			e.ID = id
+		} else {
+			e.ID = int64(len(db.events) + 1)
+		}
+	}
+	e.IDRaw = strconv.FormatInt(e.ID, 10)
+	db.events = append(db.events, &e)
+}
+
+// AddMarket 向 Gamma 假服务添加一个市场；ID 为空时自动分配。
+func (s *Server) AddMarket(m pm.Market) {
+	db := s.gammaDB
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if m.ID == "" {
+		m.ID = strconv.Itoa(len(db.markets) + 1)
+	}
+	db.markets = append(db.markets, &m)
+}
+
+// AddTag 向 Gamma 假服务添加一个标签，related 为相关标签的 ID（按排序）；ID 为空时自动分配。
+func (s *Server) AddTag(t pm.Tag, related ...string) {
+	db := s.gammaDB
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if t.ID == "" {
+		t.ID = strconv.Itoa(len(db.tags) + 1)
+	}
+	db.tags = append(db.tags, &t)
+	db.related[t.ID] = append(db.related[t.ID], related...)
+}
+
+// AddSeries 向 Gamma 假服务添加一个系列；ID 为空时自动分配。
+func (s *Server) AddSeries(sr pm.Series) {
+	db := s.gammaDB
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if sr.ID == "" {
+		sr.ID = strconv.Itoa(len(db.series) + 1)
+	}
+	db.series = append(db.series, &sr)
+}
+
+// AddSport 向 Gamma 假服务添加联赛元数据；ID 为 0 时自动分配。
+func (s *Server) AddSport(sp pm.Sport) {
+	db := s.gammaDB
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if sp.ID == 0 {
+		sp.ID = pm.FlexInt(len(db.sports) + 1)
+	}
+	db.sports = append(db.sports, &sp)
+}
+
+// AddTeam 向 Gamma 假服务添加一支球队；ID 为 0 时自动分配。
+func (s *Server) AddTeam(t pm.Team) {
+	db := s.gammaDB
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if t.ID == 0 {
+		t.ID = pm.FlexInt(len(db.teams) + 1)
+	}
+	db.teams = append(db.teams, &t)
+}
+
+// AddComment 向 Gamma 假服务添加一条评论；ID 为空时自动分配。
+func (s *Server) AddComment(c pm.Comment) {
+	db := s.gammaDB
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if c.ID == "" {
+		c.ID = strconv.Itoa(len(db.comments) + 1)
+	}
+	db.comments = append(db.comments, &c)
+}
+
+// AddProfile 向 Gamma 假服务添加公开资料（按 ProxyWallet 查询，不区分大小写）。
+func (s *Server) AddProfile(p pm.PublicProfile) {
+	db := s.gammaDB
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.profiles[strings.ToLower(p.ProxyWallet)] = &p
+}
+
+func (s *Server) gammaHandler() http.Handler {
+	mux := http.NewServeMux()
+	db := s.gammaDB
+
+	mux.HandleFunc("GET /events", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		ids := splitList(q.Get("id"))
+		slugs := splitList(q.Get("slug"))
+		closed := q.Get("closed")
+
+		db.mu.Lock()
+		var out []*pm.Event
+		for _, e := range db.events {
+			if len(ids) > 0 && !contains(ids, e.IDRaw) {
+				continue
+			}
+			if len(slugs) > 0 && !contains(slugs, e.Slug) {
+				continue
+			}
+			if closed != "" && strconv.FormatBool(e.Closed) != closed {
+				continue
+			}
+			out = append(out, e)
+		}
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, window(out, q.Get("limit"), q.Get("offset")))
+	})
+
+	mux.HandleFunc("GET /events/slug/{slug}", func(w http.ResponseWriter, r *http.Request) {
+		slug := r.PathValue("slug")
+		db.mu.Lock()
+		defer db.mu.Unlock()
+		for _, e := range db.events {
+			if e.Slug == slug {
+				writeJSON(w, http.StatusOK, e)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "event not found")
+	})
+
+	mux.HandleFunc("GET /events/{id}", func(w http.ResponseWriter, r *http.Request) {
+		db.mu.Lock()
+		defer db.mu.Unlock()
+		for _, e := range db.events {
+			if e.IDRaw == r.PathValue("id") {
+				writeJSON(w, http.StatusOK, e)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "event not found")
+	})
+
+	mux.HandleFunc("GET /markets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		db.writeMarket(w, func(m *pm.Market) bool { return m.ID == r.PathValue("id") })
+	})
+	mux.HandleFunc("GET /markets/slug/{slug}", func(w http.ResponseWriter, r *http.Request) {
+		db.writeMarket(w, func(m *pm.Market) bool { return m.Slug == r.PathValue("slug") })
+	})
+
+	mux.HandleFunc("GET /public-search", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		term := strings.ToLower(q.Get("q"))
+		if term == "" {
+			writeError(w, http.StatusBadRequest, "q is required")
+			return
+		}
+		limit, _ := strconv.Atoi(q.Get("limit_per_type"))
+		if limit <= 0 {
+			limit = 10
+		}
+		page, _ := strconv.Atoi(q.Get("page"))
+		page = max(page, 1)
+
+		db.mu.Lock()
+		var events []*pm.Event
+		for _, e := range db.events {
+			if strings.Contains(strings.ToLower(e.Title), term) || strings.Contains(strings.ToLower(e.Slug), term) {
+				events = append(events, e)
+			}
+		}
+		tags := []*pm.SearchTag{}
+		if q.Get("search_tags") == "true" {
+			for _, t := range db.tags {
+				if strings.Contains(strings.ToLower(t.Label), term) {
+					tags = append(tags, &pm.SearchTag{ID: t.ID, Label: t.Label, Slug: t.Slug})
+				}
+			}
+		}
+		db.mu.Unlock()
+
+		offset := (page - 1) * limit
+		writeJSON(w, http.StatusOK, pm.SearchResults{
+			Events:   window(events, strconv.Itoa(limit), strconv.Itoa(offset)),
+			Tags:     tags,
+			Profiles: []*pm.SearchProfile{},
+			Pagination: pm.SearchPagination{
+				HasMore:      offset+limit < len(events),
+				TotalResults: len(events),
+			},
+		})
+	})
+
+	mux.HandleFunc("GET /markets", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		ids := splitList(q.Get("id"))
+		slug := q.Get("slug")
+		tokens := splitList(q.Get("clob_token_ids"))
+		conditions := splitList(q.Get("condition_ids"))
+		closed := q.Get("closed")
+
+		db.mu.Lock()
+		var out []*pm.Market
+		for _, m := range db.markets {
+			if len(ids) > 0 && !contains(ids, m.ID) {
+				continue
+			}
+			if slug != "" && m.Slug != slug {
+				continue
+			}
+			if len(conditions) > 0 && !contains(conditions, m.ConditionID) {
+				continue
+			}
+			if len(tokens) > 0 && !anyContains(tokens, m.ClobTokenIds) {
+				continue
+			}
+			if closed != "" && strconv.FormatBool(m.Closed) != closed {
+				continue
+			}
+			out = append(out, m)
+		}
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, window(out, q.Get("limit"), q.Get("offset")))
+	})
+
+	mux.HandleFunc("GET /tags", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		db.mu.Lock()
+		out := window(db.tags, q.Get("limit"), q.Get("offset"))
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, out)
+	})
+	mux.HandleFunc("GET /tags/{path...}", db.handleTag)
+
+	mux.HandleFunc("GET /series", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		slugs := q["slug"]
+		closed := q.Get("closed")
+		db.mu.Lock()
+		var out []*pm.Series
+		for _, sr := range db.series {
+			if len(slugs) > 0 && !contains(slugs, sr.Slug) {
+				continue
+			}
+			if closed != "" && strconv.FormatBool(sr.Closed) != closed {
+				continue
+			}
+			out = append(out, sr)
+		}
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, window(out, q.Get("limit"), q.Get("offset")))
+	})
+	mux.HandleFunc("GET /series/{id}", func(w http.ResponseWriter, r *http.Request) {
+		db.mu.Lock()
+		defer db.mu.Unlock()
+		for _, sr := range db.series {
+			if sr.ID == r.PathValue("id") {
+				writeJSON(w, http.StatusOK, sr)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "series not found")
+	})
+
+	mux.HandleFunc("GET /sports", func(w http.ResponseWriter, _ *http.Request) {
+		db.mu.Lock()
+		out := window(db.sports, "", "")
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, out)
+	})
+	mux.HandleFunc("GET /teams", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		leagues, names, abbrs := q["league"], q["name"], q["abbreviation"]
+		db.mu.Lock()
+		var out []*pm.Team
+		for _, t := range db.teams {
+			if (len(leagues) > 0 && !contains(leagues, t.League)) ||
+				(len(names) > 0 && !contains(names, t.Name)) ||
+				(len(abbrs) > 0 && !contains(abbrs, t.Abbreviation)) {
+				continue
+			}
+			out = append(out, t)
+		}
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, window(out, q.Get("limit"), q.Get("offset")))
+	})
+
+	mux.HandleFunc("GET /comments", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		db.mu.Lock()
+		var out []*pm.Comment
+		for _, c := range db.comments {
+			if t := q.Get("parent_entity_type"); t != "" && c.ParentEntityType != t {
+				continue
+			}
+			if id := q.Get("parent_entity_id"); id != "" && strconv.Itoa(int(c.ParentEntityID)) != id {
+				continue
+			}
+			out = append(out, c)
+		}
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, window(out, q.Get("limit"), q.Get("offset")))
+	})
+
+	mux.HandleFunc("GET /comments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		db.mu.Lock()
+		out := []*pm.Comment{}
+		for _, c := range db.comments {
+			if c.ID == id || c.ParentCommentID == id {
+				out = append(out, c)
+			}
+		}
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, out)
+	})
+
+	mux.HandleFunc("GET /comments/user_address/{address}", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		address := r.PathValue("address")
+		db.mu.Lock()
+		var out []*pm.Comment
+		for _, c := range db.comments {
+			if strings.EqualFold(c.UserAddress, address) {
+				out = append(out, c)
+			}
+		}
+		db.mu.Unlock()
+		writeJSON(w, http.StatusOK, window(out, q.Get("limit"), q.Get("offset")))
+	})
+
+	mux.HandleFunc("GET /public-profile", func(w http.ResponseWriter, r *http.Request) {
+		db.mu.Lock()
+		p := db.profiles[strings.ToLower(r.URL.Query().Get("address"))]
+		db.mu.Unlock()
+		if p == nil {
+			writeError(w, http.StatusNotFound, "profile not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, p)
+	})
+
+	return mux
+}
+
+// writeMarket 输出第一个匹配的市场，未找到时返回 404。
+func (db *gammaStore) writeMarket(w http.ResponseWriter, match func(*pm.Market) bool) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, m := range db.markets {
+		if match(m) {
+			writeJSON(w, http.StatusOK, m)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "market not found")
+}
+
+// handleTag 处理 /tags/{id}[/related-tags[/tags]] 与 /tags/slug/{slug}[/related-tags[/tags]]。
+func (db *gammaStore) handleTag(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.PathValue("path"), "/")
+	match := func(t *pm.Tag) bool { return t.ID == parts[0] }
+	if parts[0] == "slug" && len(parts) > 1 {
+		slug := parts[1]
+		match = func(t *pm.Tag) bool { return t.Slug == slug }
+		parts = parts[1:]
+	}
+	rest := strings.Join(parts[1:], "/")
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var tag *pm.Tag
+	for _, t := range db.tags {
+		if match(t) {
+			tag = t
+			break
+		}
+	}
+	if tag == nil {
+		writeError(w, http.StatusNotFound, "tag not found")
+		return
+	}
+
+	switch rest {
+	case "":
+		writeJSON(w, http.StatusOK, tag)
+	case "related-tags":
+		id, _ := strconv.ParseInt(tag.ID, 10, 64)
+		out := make([]pm.RelatedTag, 0, len(db.related[tag.ID]))
+		for i, rel := range db.related[tag.ID] {
+			relID, _ := strconv.ParseInt(rel, 10, 64)
+			out = append(out, pm.RelatedTag{ID: pm.FlexInt(i + 1), TagID: pm.FlexInt(id), RelatedTagID: pm.FlexInt(relID), Rank: i + 1})
+		}
+		writeJSON(w, http.StatusOK, out)
+	case "related-tags/tags":
+		out := []*pm.Tag{}
+		for _, rel := range db.related[tag.ID] {
+			for _, t := range db.tags {
+				if t.ID == rel {
+					out = append(out, t)
+				}
+			}
+		}
+		writeJSON(w, http.StatusOK, out)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// window 按 limit/offset 截取列表；结果总是非 nil（序列化为 []）。
+func window[T any](items []T, limit, offset string) []T {
+	off, _ := strconv.Atoi(offset)
+	if off < 0 {
+		off = 0
+	}
+	if off > len(items) {
+		off = len(items)
+	}
+	items = items[off:]
+	if n, err := strconv.Atoi(limit); err == nil && n > 0 && n < len(items) {
+		items = items[:n]
+	}
+	return append(make([]T, 0, len(items)), items...)
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func contains(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func anyContains(list, values []string) bool {
+	for _, v := range values {
+		if contains(list, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+// server.go 模块
+
+// Package polymarkettest 提供进程内的 Polymarket 假服务，用于离线集成测试。
+//
+// Server 同时模拟 CLOB REST、Gamma REST、Data API、market/user websocket 与 RTDS，
+// 支持脚本化订单簿、简单撮合、L2 HMAC 校验与故障注入：
+//
+//	srv := polymarkettest.NewServer(polymarkettest.Options{})
+//	defer srv.Close()
+//	srv.SetBook("token-yes", []polymarkettest.Level{{Price: 0.45, Size: 100}}, []polymarkettest.Level{{Price: 0.55, Size: 100}})
+//	sdk, _ := polymarket.New(srv.Config())
+package polymarkettest
+
+import (
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	pm "github.com/dcsunny/polymarket-sdk"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultAuthSkew L2 时间戳允许的最大偏差。
+const DefaultAuthSkew = 30 * time.Second
+
+// Options 配置假服务。
+type Options struct {
+	// APIKey / APISecret / Passphrase 为 L2 凭证；为空时自动生成。APISecret 需为 base64。
+	APIKey     string
+	APISecret  string
+	Passphrase string
+
+	// PrivateKey 账户私钥（十六进制）；为空时自动生成。
+	PrivateKey string
+
+	// ClockOffset 服务器时钟相对本地时钟的偏移（用于测试时钟校正）。
+	ClockOffset time.Duration
+	// AuthSkew L2 时间戳允许的最大偏差；0 使用 DefaultAuthSkew，<0 关闭校验。
+	AuthSkew time.Duration
+	// DisableAuth 关闭 L2 签名校验（只检查头是否存在）。
+	DisableAuth bool
+}
+
+// RecordedRequest 记录一次 HTTP 请求。
+type RecordedRequest struct {
+	Service string
+	Method  string
+	Path    string
+	Query   string
+	Body    string
+}
+
+// Server 是进程内的 Polymarket 假服务。
+type Server struct {
+	opts Options
+
+	clob  *httptest.Server
+	gamma *httptest.Server
+	data  *httptest.Server
+	ws    *httptest.Server
+	rtds  *httptest.Server
+
+	privateKey *ecdsa.PrivateKey
+	address    string
+
+	mu       sync.Mutex
+	faults   []*faultState
+	requests []RecordedRequest
+	pageSize int
+
+	exchange *exchange
+	hub      *wsHub
+	gammaDB  *gammaStore
+	dataDB   *dataStore
+}
+
+// NewServer 启动假服务。调用方需在结束时调用 Close。
+func NewServer(opts Options) *Server {
+	if opts.APIKey == "" {
+		opts.APIKey = "test-api-key"
+	}
+	if opts.APISecret == "" {
+		opts.APISecret = base64.URLEncoding.EncodeToString([]byte("polymarkettest-secret-0123456789"))
+	}
+	if opts.Passphrase == "" {
+		opts.Passphrase = "test-passphrase"
+	}
+	if opts.AuthSkew == 0 {
+		opts.AuthSkew = DefaultAuthSkew
+	}
+
+	s := &Server{opts: opts}
+	if opts.PrivateKey != "" {
+		pk, err := crypto.HexToECDSA(strings.TrimPrefix(opts.PrivateKey, "0x"))
+		if err != nil {
+			panic("polymarkettest: invalid private key: " + err.Error())
+		}
+		s.privateKey = pk
+	} else {
+		pk, err := crypto.GenerateKey()
+		if err != nil {
+			panic("polymarkettest: generate key: " + err.Error())
+		}
+		s.privateKey = pk
+	}
+	s.address = crypto.PubkeyToAddress(s.privateKey.PublicKey).Hex()
+
+	s.hub = newWSHub(s)
+	s.exchange = newExchange(s)
+	s.gammaDB = newGammaStore()
+	s.dataDB = newDataStore()
+
+	s.clob = httptest.NewServer(s.wrap("clob", s.clobHandler()))
+	s.gamma = httptest.NewServer(s.wrap("gamma", s.gammaHandler()))
+	s.data = httptest.NewServer(s.wrap("data", s.dataHandler()))
+	s.ws = httptest.NewServer(s.hub.clobHandler())
+	s.rtds = httptest.NewServer(s.hub.rtdsHandler())
+	return s
+}
+
+// Close 关闭所有连接与监听。
+func (s *Server) Close() {
+	s.hub.closeAll()
+	s.clob.Close()
+	s.gamma.Close()
+	s.data.Close()
+	s.ws.Close()
+	s.rtds.Close()
+}
+
+// Config 返回指向假服务的 SDK 配置（含 L2 凭证与账户私钥）。
+func (s *Server) Config() pm.Config {
+	wsURL := "ws" + strings.TrimPrefix(s.ws.URL, "http")
+	return pm.Config{
+		BaseURL:      s.gamma.URL,
+		CLOBBaseURL:  s.clob.URL,
+		DataAPIURL:   s.data.URL,
+		WSSMarketURL: wsURL + "/ws/market",
+		WSSUserURL:   wsURL + "/ws/user",
+		RTDSURL:      "ws" + strings.TrimPrefix(s.rtds.URL, "http"),
+
+		Address:    s.address,
+		PrivateKey: "0x" + hex.EncodeToString(crypto.FromECDSA(s.privateKey)),
+		APIKey:     s.opts.APIKey,
+		APISecret:  s.opts.APISecret,
+		Passphrase: s.opts.Passphrase,
+	}
+}
+
+// Address 返回测试账户地址。
+func (s *Server) Address() string {
+	return s.address
+}
+
+// Now 返回服务器时间（本地时间 + ClockOffset）。
+func (s *Server) Now() time.Time {
+	return time.Now().Add(s.opts.ClockOffset)
+}
+
+// Requests 返回已收到的 HTTP 请求（按到达顺序）。
+func (s *Server) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RecordedRequest(nil), s.requests...)
+}
+
+// ResetRequests 清空请求记录。
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	s.requests = nil
+	s.mu.Unlock()
+}
+
+func (s *Server) wrap(service string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+
+		s.mu.Lock()
+		s.requests = append(s.requests, RecordedRequest{
+			Service: service,
+			Method:  r.Method,
+			Path:    r.URL.Path,
+			Query:   r.URL.RawQuery,
+			Body:    string(body),
+		})
+		s.mu.Unlock()
+
+		if s.applyFault(service, w, r) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,253 @@
+// server_test.go 模块
+package polymarkettest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	pm "github.com/dcsunny/polymarket-sdk"
+)
+
+const (
+	testAsset  = "123"
+	testMarket = "0xabc"
+)
+
+func newTestServer(t *testing.T) (*Server, *pm.SDK) {
+	t.Helper()
+	srv := NewServer(Options{})
+	t.Cleanup(srv.Close)
+	srv.SetMarket(MarketSpec{AssetID: testAsset, Market: testMarket, TickSize: 0.01, MinOrderSize: 1})
+	srv.SetBook(testAsset, []Level{{Price: 0.45, Size: 100}}, []Level{{Price: 0.55, Size: 4}})
+
+	sdk, err := pm.New(srv.Config())
+	if err != nil {
+		t.Fatalf("new sdk: %v", err)
+	}
+	t.Cleanup(func() { _ = sdk.WSS.Close() })
+	return srv, sdk
+}
+
+// buyOrder 以 0.55 买入 10 份（金额为 1e6 精度）。
+func buyOrder(t *testing.T, sdk *pm.SDK) *pm.OrderResponse {
+	t.Helper()
+	signed, err := sdk.CLOB.CreateOrder(&pm.OrderArgs{
+		TokenID:     testAsset,
+		MakerAmount: "5500000",
+		TakerAmount: "10000000",
+		Side:        pm.SideBuy,
+	})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	resp, err := sdk.CLOB.PostOrder(context.Background(), signed, pm.OrderTypeGTC)
+	if err != nil {
+		t.Fatalf("post order: %v", err)
+	}
+	return resp
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func receive[T any](t *testing.T, ch <-chan T, match func(T) bool) T {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case v := <-ch:
+			if match(v) {
+				return v
+			}
+		case <-timeout:
+			var zero T
+			t.Fatalf("timeout waiting for %T", zero)
+			return zero
+		}
+	}
+}
+
+func (s *Server) userAuthed() bool {
+	for _, p := range s.hub.snapshot() {
+		p.mu.Lock()
+		ok := p.kind == wsKindUser && p.authed
+		p.mu.Unlock()
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPlaceMatchCancel(t *testing.T) {
+	srv, sdk := newTestServer(t)
+
+	books := make(chan *pm.WSSBookMessage, 16)
+	orders := make(chan *pm.WSSOrderEvent, 16)
+	trades := make(chan *pm.WSSTradeEvent, 16)
+
+	market := sdk.WSS.Market()
+	market.OnBook(func(m *pm.WSSBookMessage) { books <- m })
+	if err := market.Subscribe(testAsset); err != nil {
+		t.Fatalf("subscribe market: %v", err)
+	}
+	if err := market.Connect(); err != nil {
+		t.Fatalf("connect market: %v", err)
+	}
+	snap := receive(t, books, func(*pm.WSSBookMessage) bool { return true })
+	if snap.AssetID != testAsset || len(snap.Asks) != 1 || snap.Asks[0].Price != "0.55" {
+		t.Fatalf("unexpected initial book: %+v", snap)
+	}
+
+	user := sdk.WSS.User()
+	user.OnOrder(func(e *pm.WSSOrderEvent) { orders <- e })
+	user.OnTrade(func(e *pm.WSSTradeEvent) { trades <- e })
+	if err := user.Subscribe(testMarket); err != nil {
+		t.Fatalf("subscribe user: %v", err)
+	}
+	if err := user.Connect(); err != nil {
+		t.Fatalf("connect user: %v", err)
+	}
+	waitFor(t, "user channel auth", srv.userAuthed)
+
+	// 4 份与外部卖单成交，剩余 6 份以 0.55 挂在买方。
+	resp := buyOrder(t, sdk)
+	if err := resp.Err(); err != nil || resp.Status != "live" {
+		t.Fatalf("post order: status=%q err=%v", resp.Status, err)
+	}
+
+	trade := receive(t, trades, func(*pm.WSSTradeEvent) bool { return true })
+	if trade.Size != "4" || trade.Price != "0.55" || trade.TakerOrderID != resp.OrderID {
+		t.Fatalf("unexpected trade event: %+v", trade)
+	}
+	receive(t, orders, func(e *pm.WSSOrderEvent) bool { return e.ID == resp.OrderID && e.Type == "PLACEMENT" })
+	book := receive(t, books, func(m *pm.WSSBookMessage) bool { return len(m.Asks) == 0 })
+	if best := book.Bids[len(book.Bids)-1]; best.Price != "0.55" || best.Size != "6" {
+		t.Fatalf("resting bid not in book: %+v", book.Bids)
+	}
+	if got := srv.Trades(); len(got) != 1 {
+		t.Fatalf("server trades = %d, want 1", len(got))
+	}
+
+	open, err := sdk.CLOB.GetActiveOrders(context.Background(), &pm.GetActiveOrdersRequest{})
+	if err != nil || len(open) != 1 || open[0].SizeMatched != "4" {
+		t.Fatalf("active orders = %+v, err=%v", open, err)
+	}
+
+	cancel, err := sdk.CLOB.CancelOrder(context.Background(), resp.OrderID)
+	if err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	if len(cancel.Canceled) != 1 || cancel.Canceled[0] != resp.OrderID {
+		t.Fatalf("canceled = %v", cancel.Canceled)
+	}
+	receive(t, orders, func(e *pm.WSSOrderEvent) bool { return e.ID == resp.OrderID && e.Type == "CANCELLATION" })
+	receive(t, books, func(m *pm.WSSBookMessage) bool { return len(m.Bids) == 1 && m.Bids[0].Price == "0.45" })
+}
+
+func TestL2AuthRejected(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	cfg := srv.Config()
+	cfg.APISecret = "d3Jvbmctc2VjcmV0LXdyb25nLXNlY3JldC13cm9uZw=="
+	sdk, err := pm.New(cfg)
+	if err != nil {
+		t.Fatalf("new sdk: %v", err)
+	}
+	_, err = sdk.CLOB.CancelAll(context.Background())
+	if !errors.Is(err, pm.ErrUnauthorized) {
+		t.Fatalf("cancel all with bad secret: err=%v, want ErrUnauthorized", err)
+	}
+}
+
+func TestInjectedFaults(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault Fault
+		want  error
+	}{
+		{
+			name:  "rate limited",
+			fault: Fault{Service: ServiceCLOB, Path: pm.EndpointPostOrder, Status: 429, Times: 1},
+			want:  pm.ErrRateLimited,
+		},
+		{
+			name:  "insufficient balance",
+			fault: Fault{Path: pm.EndpointPostOrder, Status: 400, Body: `{"error":"not enough balance / allowance"}`, Times: 1},
+			want:  pm.ErrInsufficientBalance,
+		},
+		{
+			// HTTP 200 但 success=false：PostOrder 应返回 *OrderError 而不是 nil。
+			name:  "rejected in body",
+			fault: Fault{Path: pm.EndpointPostOrder, Status: 200, Body: `{"success":false,"errorMsg":"order 0x1 is invalid. Duplicated."}`, Times: 1},
+			want:  pm.ErrDuplicateOrder,
+		},
+		{
+			name:  "server error",
+			fault: Fault{Method: "POST", Path: "/ord*", Status: 503, Times: 1},
+			want:  pm.ErrServer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, sdk := newTestServer(t)
+			srv.InjectFault(tt.fault)
+
+			signed, err := sdk.CLOB.CreateOrder(&pm.OrderArgs{
+				TokenID:     testAsset,
+				MakerAmount: "5500000",
+				TakerAmount: "10000000",
+				Side:        pm.SideBuy,
+			})
+			if err != nil {
+				t.Fatalf("create order: %v", err)
+			}
+			_, err = sdk.CLOB.PostOrder(context.Background(), signed, pm.OrderTypeGTC)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+
+			// Times 用尽后恢复正常。
+			if resp := buyOrder(t, sdk); !resp.Success {
+				t.Fatalf("order after fault: %+v", resp)
+			}
+		})
+	}
+}
+
+func TestPostOrdersPartialReject(t *testing.T) {
+	_, sdk := newTestServer(t)
+
+	var args []pm.PostOrdersArgs
+	for _, maker := range []string{"5500000", "5550000"} {
+		signed, err := sdk.CLOB.CreateOrder(&pm.OrderArgs{
+			TokenID:     testAsset,
+			MakerAmount: maker,
+			TakerAmount: "10000000",
+			Side:        pm.SideBuy,
+		})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		args = append(args, pm.PostOrdersArgs{Order: signed, OrderType: pm.OrderTypeGTC})
+	}
+	resp, err := sdk.CLOB.PostOrdersSigned(context.Background(), args, pm.PostOrdersOptions{})
+	if len(resp) != 2 || !resp[0].Success || resp[1].Err() == nil {
+		t.Fatalf("responses = %+v", resp)
+	}
+	var oe *pm.OrderError
+	if !errors.Is(err, pm.ErrInvalidTickSize) || !errors.As(err, &oe) || !strings.Contains(err.Error(), "order 1:") {
+		t.Fatalf("err = %v, want *OrderError for order 1 matching ErrInvalidTickSize", err)
+	}
+}
@@ -0,0 +1,404 @@
+// ws.go 模块
+package polymarkettest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	pm "github.com/dcsunny/polymarket-sdk"
+	"github.com/gorilla/websocket"
+)
+
+type wsKind int
+
+const (
+	wsKindMarket wsKind = iota
+	wsKindUser
+	wsKindRTDS
+)
+
+type rtdsSub struct {
+	topic string
+	typ   string
+}
+
+type wsPeer struct {
+	kind wsKind
+	conn *websocket.Conn
+
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	assets  map[string]bool
+	markets map[string]bool
+	authed  bool
+	rtds    []rtdsSub
+}
+
+func (p *wsPeer) write(v any) error {
+	var data []byte
+	switch m := v.(type) {
+	case string:
+		data = []byte(m)
+	case []byte:
+		data = m
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return err
+		}
+	}
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	_ = p.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	return p.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+type wsHub struct {
+	s        *Server
+	upgrader websocket.Upgrader
+
+	mu    sync.Mutex
+	peers map[*wsPeer]bool
+}
+
+func newWSHub(s *Server) *wsHub {
+	return &wsHub{
+		s:        s,
+		upgrader: websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }},
+		peers:    make(map[*wsPeer]bool),
+	}
+}
+
+func (h *wsHub) clobHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws/market", func(w http.ResponseWriter, r *http.Request) { h.serve(w, r, wsKindMarket) })
+	mux.HandleFunc("/ws/user", func(w http.ResponseWriter, r *http.Request) { h.serve(w, r, wsKindUser) })
+	return mux
+}
+
+func (h *wsHub) rtdsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { h.serve(w, r, wsKindRTDS) })
+}
+
+func (h *wsHub) serve(w http.ResponseWriter, r *http.Request, kind wsKind) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	p := &wsPeer{kind: kind, conn: conn, assets: map[string]bool{}, markets: map[string]bool{}}
+	h.mu.Lock()
+	h.peers[p] = true
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.peers, p)
+		h.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if string(data) == "PING" {
+			_ = p.write("PONG")
+			continue
+		}
+		switch kind {
+		case wsKindMarket:
+			h.handleMarketSub(p, data)
+		case wsKindUser:
+			if !h.handleUserSub(p, data) {
+				return
+			}
+		case wsKindRTDS:
+			if !h.handleRTDSSub(p, data) {
+				return
+			}
+		}
+	}
+}
+
+type clobSubMessage struct {
+	Auth      *pm.WSSAuth `json:"auth"`
+	Type      string      `json:"type"`
+	Operation string      `json:"operation"`
+	Markets   []string    `json:"markets"`
+	AssetsIDs []string    `json:"assets_ids"`
+}
+
+func (h *wsHub) handleMarketSub(p *wsPeer, data []byte) {
+	var msg clobSubMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return
+	}
+	p.mu.Lock()
+	for _, id := range msg.AssetsIDs {
+		if msg.Operation == "unsubscribe" {
+			delete(p.assets, id)
+		} else {
+			p.assets[id] = true
+		}
+	}
+	p.mu.Unlock()
+	if msg.Operation == "unsubscribe" {
+		return
+	}
+
+	var books []any
+	for _, id := range msg.AssetsIDs {
+		if book := h.s.Book(id); book != nil {
+			books = append(books, bookEvent(*book))
+		}
+	}
+	if len(books) > 0 {
+		_ = p.write(books)
+	}
+}
+
+func (h *wsHub) handleUserSub(p *wsPeer, data []byte) bool {
+	var msg clobSubMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return true
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.authed {
+		o := h.s.opts
+		if msg.Auth == nil || msg.Auth.APIKey != o.APIKey || msg.Auth.Secret != o.APISecret || msg.Auth.Passphrase != o.Passphrase {
+			p.writeMu.Lock()
+			_ = p.conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "invalid auth"), time.Now().Add(time.Second))
+			p.writeMu.Unlock()
+			return false
+		}
+		p.authed = true
+	}
+	for _, m := range msg.Markets {
+		if msg.Operation == "unsubscribe" {
+			delete(p.markets, m)
+		} else {
+			p.markets[m] = true
+		}
+	}
+	return true
+}
+
+type rtdsSubMessage struct {
+	Action        string `json:"action"`
+	Subscriptions []struct {
+		Topic    string           `json:"topic"`
+		Type     string           `json:"type"`
+		ClobAuth *pm.RTDSClobAuth `json:"clob_auth"`
+	} `json:"subscriptions"`
+}
+
+// handleRTDSSub 处理 RTDS 订阅；clob_user 主题的 clob_auth 无效时以 policy violation 关闭连接并返回 false。
+func (h *wsHub) handleRTDSSub(p *wsPeer, data []byte) bool {
+	var msg rtdsSubMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return true
+	}
+	o := h.s.opts
+	for _, sub := range msg.Subscriptions {
+		if msg.Action != "subscribe" || sub.Topic != pm.RTDSTopicClobUser {
+			continue
+		}
+		a := sub.ClobAuth
+		if a == nil || (!o.DisableAuth && (a.Key != o.APIKey || a.Secret != o.APISecret || a.Passphrase != o.Passphrase)) {
+			p.writeMu.Lock()
+			_ = p.conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "invalid clob_auth"), time.Now().Add(time.Second))
+			p.writeMu.Unlock()
+			return false
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, sub := range msg.Subscriptions {
+		typ := sub.Type
+		if typ == "" {
+			typ = "*"
+		}
+		switch msg.Action {
+		case "subscribe":
+			p.rtds = append(p.rtds, rtdsSub{topic: sub.Topic, typ: typ})
+		case "unsubscribe":
+			kept := p.rtds[:0]
+			for _, s := range p.rtds {
+				if s.topic != sub.Topic || (sub.Type != "" && s.typ != sub.Type) {
+					kept = append(kept, s)
+				}
+			}
+			p.rtds = kept
+		}
+	}
+	return true
+}
+
+func (h *wsHub) snapshot() []*wsPeer {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	out := make([]*wsPeer, 0, len(h.peers))
+	for p := range h.peers {
+		out = append(out, p)
+	}
+	return out
+}
+
+func (h *wsHub) closeAll() {
+	for _, p := range h.snapshot() {
+		_ = p.conn.Close()
+	}
+}
+
+// publishMarket 向订阅了 assetID 的 market 连接推送订单簿快照。
+func (h *wsHub) publishMarket(assetID string, book pm.OrderBookSummary) {
+	h.publishMarketEvent(assetID, bookEvent(book))
+}
+
+func (h *wsHub) publishMarketEvent(assetID string, event any) {
+	for _, p := range h.snapshot() {
+		if p.kind != wsKindMarket {
+			continue
+		}
+		p.mu.Lock()
+		ok := p.assets[assetID]
+		p.mu.Unlock()
+		if ok {
+			_ = p.write(event)
+		}
+	}
+}
+
+func (h *wsHub) publishUser(events []any) {
+	for _, ev := range events {
+		market := ""
+		if oe, ok := ev.(pm.WSSOrderEvent); ok {
+			market = oe.Market
+		}
+		if te, ok := ev.(pm.WSSTradeEvent); ok {
+			market = te.Market
+		}
+		for _, p := range h.snapshot() {
+			if p.kind != wsKindUser {
+				continue
+			}
+			p.mu.Lock()
+			ok := p.authed && (len(p.markets) == 0 || p.markets[market])
+			p.mu.Unlock()
+			if ok {
+				_ = p.write(ev)
+			}
+		}
+	}
+}
+
+func (h *wsHub) publishUserTrades(trades []*pm.Trade) {
+	events := make([]any, 0, len(trades))
+	for _, t := range trades {
+		ts, _ := strconv.ParseInt(t.MatchTime, 10, 64)
+		events = append(events, pm.WSSTradeEvent{
+			EventType:    "trade",
+			ID:           t.ID,
+			Market:       t.Market,
+			AssetID:      t.AssetID,
+			Owner:        t.Owner,
+			Price:        t.Price,
+			Side:         t.Side,
+			Size:         t.Size,
+			Status:       t.Status,
+			Timestamp:    pm.FlexInt(ts),
+			TakerOrderID: t.TakerOrderID,
+			MakerOrders:  t.MakerOrders,
+		})
+	}
+	h.publishUser(events)
+}
+
+// PublishMarketEvent 向订阅了 assetID 的 market 连接推送任意事件（例如 price_change、tick_size_change）。
+func (s *Server) PublishMarketEvent(assetID string, event any) {
+	s.hub.publishMarketEvent(assetID, event)
+}
+
+// PublishUserEvent 向已认证的 user 连接推送任意事件。
+func (s *Server) PublishUserEvent(event any) {
+	s.hub.publishUser([]any{event})
+}
+
+// PublishRTDS 向订阅了 topic/type 的 RTDS 连接推送消息。
+func (s *Server) PublishRTDS(topic, msgType string, payload any) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	msg := struct {
+		pm.RTDSMessage
+		ConnectionID string `json:"connection_id"`
+	}{
+		RTDSMessage: pm.RTDSMessage{
+			Topic:     topic,
+			Type:      msgType,
+			Timestamp: s.Now().UnixMilli(),
+			Payload:   raw,
+		},
+		ConnectionID: "polymarkettest",
+	}
+	for _, p := range s.hub.snapshot() {
+		if p.kind != wsKindRTDS {
+			continue
+		}
+		p.mu.Lock()
+		ok := false
+		for _, sub := range p.rtds {
+			if sub.topic == topic && (sub.typ == "*" || sub.typ == msgType) {
+				ok = true
+				break
+			}
+		}
+		p.mu.Unlock()
+		if ok {
+			_ = p.write(msg)
+		}
+	}
+}
+
+// WebSocketConnections 返回当前 websocket 连接数（market、user、RTDS）。
+func (s *Server) WebSocketConnections() (market, user, rtds int) {
+	for _, p := range s.hub.snapshot() {
+		switch p.kind {
+		case wsKindMarket:
+			market++
+		case wsKindUser:
+			user++
+		case wsKindRTDS:
+			rtds++
+		}
+	}
+	return market, user, rtds
+}
+
+func bookEvent(book pm.OrderBookSummary) pm.WSSBookMessage {
+	ts, _ := strconv.ParseInt(book.Timestamp, 10, 64)
+	msg := pm.WSSBookMessage{
+		EventType: "book",
+		Market:    book.Market,
+		AssetID:   book.AssetID,
+		Timestamp: pm.FlexInt(ts),
+		Hash:      book.Hash,
+		Bids:      make([]pm.WSSOrderSummary, 0, len(book.Bids)),
+		Asks:      make([]pm.WSSOrderSummary, 0, len(book.Asks)),
+	}
+	for _, l := range book.Bids {
+		msg.Bids = append(msg.Bids, pm.WSSOrderSummary{Price: l.Price, Size: l.Size})
+	}
+	for _, l := range book.Asks {
+		msg.Asks = append(msg.Asks, pm.WSSOrderSummary{Price: l.Price, Size: l.Size})
+	}
+	return msg
+}
@@ -0,0 +1,54 @@
+// reconnect.go 模块
+package polymarket
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	DefaultReconnectInitialBackoff = 500 * time.Millisecond
+	DefaultReconnectMaxBackoff     = 30 * time.Second
+)
+
+// ErrReconnectExhausted 表示 websocket 重连次数已用尽。
+var ErrReconnectExhausted = errors.New("websocket reconnect attempts exhausted")
+
+// ReconnectPolicy 控制 websocket 断线后的自动重连。
+type ReconnectPolicy struct {
+	// Disabled 关闭自动重连（断线后只发出 disconnected 事件）。
+	Disabled bool
+	// InitialBackoff 首次重连前的等待；0 使用 DefaultReconnectInitialBackoff。
+	InitialBackoff time.Duration
+	// MaxBackoff 指数退避的上限；0 使用 DefaultReconnectMaxBackoff。
+	MaxBackoff time.Duration
+	// MaxAttempts 连续失败的最大次数；0 表示无限重试。
+	MaxAttempts int
+}
+
+// backoff 返回第 attempt 次（从 1 开始）重连前的等待时间：指数退避并带 ±20% 抖动。
+func (p ReconnectPolicy) backoff(attempt int) time.Duration {
+	base := p.InitialBackoff
+	if base <= 0 {
+		base = DefaultReconnectInitialBackoff
+	}
+	limit := p.MaxBackoff
+	if limit <= 0 {
+		limit = DefaultReconnectMaxBackoff
+	}
+	d := base
+	for i := 1; i < attempt && d < limit; i++ {
+		d *= 2
+	}
+	if d > limit {
+		d = limit
+	}
+	jitter := time.Duration((rand.Float64()*0.4 - 0.2) * float64(d))
+	return d + jitter
+}
+
+// exhausted 判断第 attempt 次重连是否超出 MaxAttempts。
+func (p ReconnectPolicy) exhausted(attempt int) bool {
+	return p.MaxAttempts > 0 && attempt > p.MaxAttempts
+}
@@ -0,0 +1,55 @@
+// reconnect_test.go 模块
+package polymarket
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReconnectBackoff(t *testing.T) {
+	custom := ReconnectPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	tests := []struct {
+		name    string
+		policy  ReconnectPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{"first attempt", custom, 1, 100 * time.Millisecond},
+		{"doubles", custom, 2, 200 * time.Millisecond},
+		{"doubles again", custom, 4, 800 * time.Millisecond},
+		{"capped", custom, 5, time.Second},
+		{"stays capped", custom, 100, time.Second},
+		{"zero attempt", custom, 0, 100 * time.Millisecond},
+		{"defaults", ReconnectPolicy{}, 1, DefaultReconnectInitialBackoff},
+		{"default cap", ReconnectPolicy{}, 1000, DefaultReconnectMaxBackoff},
+		{"initial above cap", ReconnectPolicy{InitialBackoff: time.Minute, MaxBackoff: time.Second}, 1, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo := time.Duration(float64(tt.want) * 0.8)
+			hi := time.Duration(float64(tt.want) * 1.2)
+			for i := 0; i < 200; i++ {
+				if d := tt.policy.backoff(tt.attempt); d < lo || d > hi {
+					t.Fatalf("backoff(%d) = %v, want within [%v, %v]", tt.attempt, d, lo, hi)
+				}
+			}
+		})
+	}
+}
+
+func TestReconnectExhausted(t *testing.T) {
+	tests := []struct {
+		max     int
+		attempt int
+		want    bool
+	}{
+		{0, 1000, false},
+		{3, 3, false},
+		{3, 4, true},
+	}
+	for _, tt := range tests {
+		if got := (ReconnectPolicy{MaxAttempts: tt.max}).exhausted(tt.attempt); got != tt.want {
+			t.Errorf("MaxAttempts=%d exhausted(%d) = %v, want %v", tt.max, tt.attempt, got, tt.want)
+		}
+	}
+}
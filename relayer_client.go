@@ -138,6 +138,9 @@ type RelayerConfig struct {
 	PrivateKey  string       `json:"privateKey"`
 	ChainID     int64        `json:"chainId"`
 	BuilderAuth *BuilderAuth `json:"builderAuth,omitempty"`
+
+	// HTTPClient 自定义 relayer HTTP 客户端（可选）；为空时使用 30s 超时的默认客户端。
+	HTTPClient *http.Client `json:"-"`
 }
 
 // RedeemRelayerRequest 赎回请求（重命名以避免冲突）
@@ -191,13 +194,16 @@ func NewRelayerClient(ctx context.Context, cfg RelayerConfig) (*RelayerClient, e
 		return nil, fmt.Errorf("解析私钥失败: %w", err)
 	}
 
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
 	client := &RelayerClient{
 		config:     cfg,
 		ethClient:  ethClient,
 		privateKey: privateKey,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		httpClient: httpClient,
 	}
 
 	// 派生 Safe 地址
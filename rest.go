@@ -152,6 +152,31 @@ func (c *RESTClient) EventBySlug(ctx context.Context, slug string, q EventBySlug
 	return &event, nil
 }
 
+// EventByIDQuery 控制按 ID 获取事件的选项（与 slug 获取相同）。
+type EventByIDQuery = EventBySlugQuery
+
+// EventByID 根据 ID 获取单个事件。
+func (c *RESTClient) EventByID(ctx context.Context, id int64, q EventByIDQuery) (*Event, error) {
+	if id <= 0 {
+		return nil, ErrInvalidArgument("id is required")
+	}
+
+	vals := url.Values{}
+	if q.IncludeChat != nil {
+		vals.Set("include_chat", strconv.FormatBool(*q.IncludeChat))
+	}
+	if q.IncludeTemplate != nil {
+		vals.Set("include_template", strconv.FormatBool(*q.IncludeTemplate))
+	}
+
+	var event Event
+	path := "/events/" + strconv.FormatInt(id, 10)
+	if err := c.http.Do(ctx, http.MethodGet, path, vals, nil, nil, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
 // MarketsQuery 过滤市场列表。
 type MarketsQuery struct {
 	Limit  int
@@ -275,3 +300,37 @@ func (c *RESTClient) Markets(ctx context.Context, q MarketsQuery) ([]*Market, er
 	}
 	return markets, nil
 }
+
+// MarketQuery 控制单个市场获取选项。
+type MarketQuery struct {
+	IncludeTag *bool
+}
+
+// MarketByID 根据 ID 获取单个市场。
+func (c *RESTClient) MarketByID(ctx context.Context, id string, q MarketQuery) (*Market, error) {
+	if id == "" {
+		return nil, ErrInvalidArgument("id is required")
+	}
+	return c.market(ctx, "/markets/"+url.PathEscape(id), q)
+}
+
+// MarketBySlug 根据 slug 获取单个市场。
+func (c *RESTClient) MarketBySlug(ctx context.Context, slug string, q MarketQuery) (*Market, error) {
+	if slug == "" {
+		return nil, ErrInvalidArgument("slug is required")
+	}
+	return c.market(ctx, "/markets/slug/"+url.PathEscape(slug), q)
+}
+
+func (c *RESTClient) market(ctx context.Context, path string, q MarketQuery) (*Market, error) {
+	vals := url.Values{}
+	if q.IncludeTag != nil {
+		vals.Set("include_tag", strconv.FormatBool(*q.IncludeTag))
+	}
+
+	var market Market
+	if err := c.http.Do(ctx, http.MethodGet, path, vals, nil, nil, &market); err != nil {
+		return nil, err
+	}
+	return &market, nil
+}
@@ -0,0 +1,160 @@
+// rest_comments.go 模块
+package polymarket
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// CommentsQuery 过滤评论列表。
+type CommentsQuery struct {
+	Limit  int
+	Offset int
+
+	Order     string
+	Ascending bool
+
+	// ParentEntityType 取值 CommentEntityEvent / CommentEntitySeries / CommentEntityMarket。
+	ParentEntityType string
+	ParentEntityID   int
+
+	// GetPositions 附带作者在该市场的持仓。
+	GetPositions *bool
+	// HoldersOnly 仅返回持有头寸的用户的评论。
+	HoldersOnly *bool
+}
+
+// Comments 返回某个事件、系列或市场下的评论。
+func (c *RESTClient) Comments(ctx context.Context, q CommentsQuery) ([]*Comment, error) {
+	if q.Limit <= 0 {
+		q.Limit = 100
+	}
+
+	vals := url.Values{}
+	vals.Set("limit", strconv.Itoa(q.Limit))
+	vals.Set("offset", strconv.Itoa(q.Offset))
+	if q.Order != "" {
+		vals.Set("order", q.Order)
+	}
+	vals.Set("ascending", strconv.FormatBool(q.Ascending))
+	if q.ParentEntityType != "" {
+		vals.Set("parent_entity_type", q.ParentEntityType)
+	}
+	if q.ParentEntityID > 0 {
+		vals.Set("parent_entity_id", strconv.Itoa(q.ParentEntityID))
+	}
+	if q.GetPositions != nil {
+		vals.Set("get_positions", strconv.FormatBool(*q.GetPositions))
+	}
+	if q.HoldersOnly != nil {
+		vals.Set("holders_only", strconv.FormatBool(*q.HoldersOnly))
+	}
+
+	var comments []*Comment
+	if err := c.http.Do(ctx, http.MethodGet, "/comments", vals, nil, nil, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// CommentsAll 逐页遍历评论（语义同 EventsAll）。
+func (c *RESTClient) CommentsAll(ctx context.Context, q CommentsQuery, opts PageOptions) iter.Seq2[*Comment, error] {
+	if q.Limit <= 0 {
+		q.Limit = DefaultGammaPageSize
+	}
+	return offsetPages(ctx, q.Limit, q.Offset, opts, func(ctx context.Context, offset int) ([]*Comment, error) {
+		page := q
+		page.Offset = offset
+		return c.Comments(ctx, page)
+	})
+}
+
+// CommentThread 返回评论及其回复（GET /comments/{id}）。
+func (c *RESTClient) CommentThread(ctx context.Context, id string, getPositions *bool) ([]*Comment, error) {
+	if id == "" {
+		return nil, ErrInvalidArgument("id is required")
+	}
+
+	vals := url.Values{}
+	if getPositions != nil {
+		vals.Set("get_positions", strconv.FormatBool(*getPositions))
+	}
+
+	var comments []*Comment
+	if err := c.http.Do(ctx, http.MethodGet, "/comments/"+url.PathEscape(id), vals, nil, nil, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// UserCommentsQuery 控制用户评论列表的分页与排序。
+type UserCommentsQuery struct {
+	Limit  int
+	Offset int
+
+	Order     string
+	Ascending bool
+}
+
+// CommentsByUser 返回某个地址发表的评论。
+func (c *RESTClient) CommentsByUser(ctx context.Context, address string, q UserCommentsQuery) ([]*Comment, error) {
+	if address == "" {
+		return nil, ErrInvalidArgument("address is required")
+	}
+	if q.Limit <= 0 {
+		q.Limit = 100
+	}
+
+	vals := url.Values{}
+	vals.Set("limit", strconv.Itoa(q.Limit))
+	vals.Set("offset", strconv.Itoa(q.Offset))
+	if q.Order != "" {
+		vals.Set("order", q.Order)
+	}
+	vals.Set("ascending", strconv.FormatBool(q.Ascending))
+
+	var comments []*Comment
+	path := "/comments/user_address/" + url.PathEscape(address)
+	if err := c.http.Do(ctx, http.MethodGet, path, vals, nil, nil, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// PublicProfile 根据钱包地址获取公开的用户资料。
+func (c *RESTClient) PublicProfile(ctx context.Context, address string) (*PublicProfile, error) {
+	if address == "" {
+		return nil, ErrInvalidArgument("address is required")
+	}
+
+	vals := url.Values{}
+	vals.Set("address", address)
+
+	var profile PublicProfile
+	if err := c.http.Do(ctx, http.MethodGet, "/public-profile", vals, nil, nil, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// NestComments 按 ParentCommentID 把平铺的评论组织为树，返回顶层评论（保持原有顺序）。
+// 父评论不在列表中的回复视为顶层评论。会覆盖各评论的 Replies。
+func NestComments(comments []*Comment) []*Comment {
+	byID := make(map[string]*Comment, len(comments))
+	for _, cm := range comments {
+		cm.Replies = nil
+		byID[cm.ID] = cm
+	}
+	var roots []*Comment
+	for _, cm := range comments {
+		if parent := byID[cm.ParentCommentID]; cm.ParentCommentID != "" && parent != nil && parent != cm {
+			parent.Replies = append(parent.Replies, cm)
+			continue
+		}
+		roots = append(roots, cm)
+	}
+	return roots
+}
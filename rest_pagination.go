@@ -0,0 +1,113 @@
+// rest_pagination.go 模块
+package polymarket
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// DefaultGammaPageSize Gamma 偏移分页的默认每页条数。
+const DefaultGammaPageSize = 100
+
+// PageOptions 控制 Gamma 偏移分页迭代。
+type PageOptions struct {
+	// Concurrency 同时拉取的页数；<= 1 时逐页顺序拉取。
+	// 并行时按批拉取 Concurrency 页，仍按偏移顺序产出。
+	Concurrency int
+}
+
+// EventsAll 从 q.Offset 开始逐页遍历事件，直到返回不足一页（q.Limit，默认 100）为止。
+// 出错或 ctx 取消时产出一次错误后结束。
+func (c *RESTClient) EventsAll(ctx context.Context, q EventsQuery, opts PageOptions) iter.Seq2[*Event, error] {
+	if q.Limit <= 0 {
+		q.Limit = DefaultGammaPageSize
+	}
+	return offsetPages(ctx, q.Limit, q.Offset, opts, func(ctx context.Context, offset int) ([]*Event, error) {
+		page := q
+		page.Offset = offset
+		return c.Events(ctx, page)
+	})
+}
+
+// MarketsAll 从 q.Offset 开始逐页遍历市场，语义同 EventsAll。
+func (c *RESTClient) MarketsAll(ctx context.Context, q MarketsQuery, opts PageOptions) iter.Seq2[*Market, error] {
+	if q.Limit <= 0 {
+		q.Limit = DefaultGammaPageSize
+	}
+	return offsetPages(ctx, q.Limit, q.Offset, opts, func(ctx context.Context, offset int) ([]*Market, error) {
+		page := q
+		page.Offset = offset
+		return c.Markets(ctx, page)
+	})
+}
+
+// CollectEvents 拉取全部事件。
+func (c *RESTClient) CollectEvents(ctx context.Context, q EventsQuery, opts PageOptions) ([]*Event, error) {
+	return Collect(c.EventsAll(ctx, q, opts))
+}
+
+// CollectMarkets 拉取全部市场。
+func (c *RESTClient) CollectMarkets(ctx context.Context, q MarketsQuery, opts PageOptions) ([]*Market, error) {
+	return Collect(c.MarketsAll(ctx, q, opts))
+}
+
+// Collect 把分页迭代器收集为切片；遇到错误时返回已收集的部分与该错误。
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var out []T
+	for v, err := range seq {
+		if err != nil {
+			return out, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+type offsetPage[T any] struct {
+	items []T
+	err   error
+}
+
+// offsetPages 通用的 limit/offset 分页迭代：返回条数少于 limit 的页视为最后一页。
+func offsetPages[T any](ctx context.Context, limit, offset int, opts PageOptions, fetch func(ctx context.Context, offset int) ([]T, error)) iter.Seq2[T, error] {
+	batch := max(opts.Concurrency, 1)
+	return func(yield func(T, error) bool) {
+		var zero T
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			pages := make([]offsetPage[T], batch)
+			if batch == 1 {
+				pages[0].items, pages[0].err = fetch(ctx, offset)
+			} else {
+				var wg sync.WaitGroup
+				for i := range pages {
+					wg.Add(1)
+					go func(i int) {
+						defer wg.Done()
+						pages[i].items, pages[i].err = fetch(ctx, offset+i*limit)
+					}(i)
+				}
+				wg.Wait()
+			}
+			for _, p := range pages {
+				if p.err != nil {
+					yield(zero, p.err)
+					return
+				}
+				for _, v := range p.items {
+					if !yield(v, nil) {
+						return
+					}
+				}
+				if len(p.items) < limit {
+					return
+				}
+			}
+			offset += batch * limit
+		}
+	}
+}
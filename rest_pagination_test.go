@@ -0,0 +1,102 @@
+// rest_pagination_test.go 模块
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// offsetSource 模拟共 total 条的 limit/offset 接口，记录请求次数与最大并发。
+type offsetSource struct {
+	total, limit int
+	failAt       int
+
+	calls    atomic.Int32
+	inflight atomic.Int32
+	mu       sync.Mutex
+	peak     int32
+}
+
+func (s *offsetSource) fetch(_ context.Context, offset int) ([]int, error) {
+	s.calls.Add(1)
+	n := s.inflight.Add(1)
+	defer s.inflight.Add(-1)
+	s.mu.Lock()
+	s.peak = max(s.peak, n)
+	s.mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+
+	if s.failAt > 0 && offset >= s.failAt {
+		return nil, errors.New("boom")
+	}
+	var out []int
+	for i := offset; i < min(offset+s.limit, s.total); i++ {
+		out = append(out, i)
+	}
+	return out, nil
+}
+
+func TestOffsetPages(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int
+		concurrency int
+		wantCalls   int32
+	}{
+		{"short last page", 250, 1, 3},
+		{"exact multiple", 200, 1, 3},
+		{"empty", 0, 1, 1},
+		{"parallel short page", 250, 4, 4},
+		{"parallel multiple batches", 950, 4, 12},
+		{"parallel exact batch", 400, 4, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &offsetSource{total: tt.total, limit: 100}
+			got, err := Collect(offsetPages(context.Background(), 100, 0, PageOptions{Concurrency: tt.concurrency}, src.fetch))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.total {
+				t.Fatalf("got %d items, want %d", len(got), tt.total)
+			}
+			for i, v := range got {
+				if v != i {
+					t.Fatalf("item %d = %d, out of order", i, v)
+				}
+			}
+			if c := src.calls.Load(); c != tt.wantCalls {
+				t.Fatalf("fetch calls = %d, want %d", c, tt.wantCalls)
+			}
+			if limit := int32(max(tt.concurrency, 1)); src.peak > limit {
+				t.Fatalf("peak concurrency = %d, want <= %d", src.peak, limit)
+			}
+		})
+	}
+}
+
+func TestOffsetPagesErrorAndEarlyStop(t *testing.T) {
+	src := &offsetSource{total: 1000, limit: 100, failAt: 300}
+	got, err := Collect(offsetPages(context.Background(), 100, 0, PageOptions{Concurrency: 2}, src.fetch))
+	if err == nil || len(got) != 300 {
+		t.Fatalf("got %d items, err %v; want 300 items and an error", len(got), err)
+	}
+
+	src = &offsetSource{total: 1000, limit: 100}
+	n := 0
+	for _, err := range offsetPages(context.Background(), 100, 50, PageOptions{}, src.fetch) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n++; n == 120 {
+			break
+		}
+	}
+	if c := src.calls.Load(); c != 2 {
+		t.Fatalf("fetch calls after early stop = %d, want 2", c)
+	}
+}
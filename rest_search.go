@@ -0,0 +1,120 @@
+// rest_search.go 模块
+package polymarket
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SearchQuery 控制公共搜索（事件、标签与用户资料；市场随事件返回）。
+type SearchQuery struct {
+	// Query 搜索关键词（必填）。
+	Query string
+
+	// Page 页码，从 1 开始；0 表示第一页。
+	Page         int
+	LimitPerType int
+
+	Sort      string
+	Ascending *bool
+
+	// EventsStatus 按事件状态过滤（如 active / closed）。
+	EventsStatus      string
+	EventsTags        []string
+	ExcludeTagIDs     []int
+	KeepClosedMarkets *bool
+	Recurrence        string
+
+	SearchTags     *bool
+	SearchProfiles *bool
+
+	Optimized *bool
+	Cache     *bool
+}
+
+// Search 执行公共搜索（GET /public-search），返回一页结果。
+func (c *RESTClient) Search(ctx context.Context, q SearchQuery) (*SearchResults, error) {
+	if q.Query == "" {
+		return nil, ErrInvalidArgument("query is required")
+	}
+
+	vals := url.Values{}
+	vals.Set("q", q.Query)
+	if q.Page > 0 {
+		vals.Set("page", strconv.Itoa(q.Page))
+	}
+	if q.LimitPerType > 0 {
+		vals.Set("limit_per_type", strconv.Itoa(q.LimitPerType))
+	}
+	if q.Sort != "" {
+		vals.Set("sort", q.Sort)
+	}
+	if q.Ascending != nil {
+		vals.Set("ascending", strconv.FormatBool(*q.Ascending))
+	}
+	if q.EventsStatus != "" {
+		vals.Set("events_status", q.EventsStatus)
+	}
+	for _, tag := range q.EventsTags {
+		vals.Add("events_tag", tag)
+	}
+	for _, id := range q.ExcludeTagIDs {
+		vals.Add("exclude_tag_id", strconv.Itoa(id))
+	}
+	if q.KeepClosedMarkets != nil {
+		// 该参数为整数开关。
+		keep := "0"
+		if *q.KeepClosedMarkets {
+			keep = "1"
+		}
+		vals.Set("keep_closed_markets", keep)
+	}
+	if q.Recurrence != "" {
+		vals.Set("recurrence", q.Recurrence)
+	}
+	if q.SearchTags != nil {
+		vals.Set("search_tags", strconv.FormatBool(*q.SearchTags))
+	}
+	if q.SearchProfiles != nil {
+		vals.Set("search_profiles", strconv.FormatBool(*q.SearchProfiles))
+	}
+	if q.Optimized != nil {
+		vals.Set("optimized", strconv.FormatBool(*q.Optimized))
+	}
+	if q.Cache != nil {
+		vals.Set("cache", strconv.FormatBool(*q.Cache))
+	}
+
+	var resp SearchResults
+	if err := c.http.Do(ctx, http.MethodGet, "/public-search", vals, nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SearchPages 从 q.Page 开始逐页搜索，直到 Pagination.HasMore 为 false。
+// 出错或 ctx 取消时产出一次错误后结束。
+func (c *RESTClient) SearchPages(ctx context.Context, q SearchQuery) iter.Seq2[*SearchResults, error] {
+	return func(yield func(*SearchResults, error) bool) {
+		page := max(q.Page, 1)
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			q.Page = page
+			res, err := c.Search(ctx, q)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(res, nil) || !res.Pagination.HasMore {
+				return
+			}
+			page++
+		}
+	}
+}
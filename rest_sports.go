@@ -0,0 +1,131 @@
+// rest_sports.go 模块
+package polymarket
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SeriesQuery 过滤系列列表。
+type SeriesQuery struct {
+	Limit  int
+	Offset int
+
+	Order     string
+	Ascending bool
+
+	Slugs          []string
+	CategoryIDs    []int
+	CategoryLabels []string
+
+	Closed      *bool
+	IncludeChat *bool
+	Recurrence  string
+}
+
+// SeriesList 返回系列列表。
+func (c *RESTClient) SeriesList(ctx context.Context, q SeriesQuery) ([]*Series, error) {
+	if q.Limit <= 0 {
+		q.Limit = 100
+	}
+
+	vals := url.Values{}
+	vals.Set("limit", strconv.Itoa(q.Limit))
+	vals.Set("offset", strconv.Itoa(q.Offset))
+	if q.Order != "" {
+		vals.Set("order", q.Order)
+	}
+	vals.Set("ascending", strconv.FormatBool(q.Ascending))
+
+	for _, slug := range q.Slugs {
+		vals.Add("slug", slug)
+	}
+	for _, id := range q.CategoryIDs {
+		vals.Add("categories_ids", strconv.Itoa(id))
+	}
+	for _, label := range q.CategoryLabels {
+		vals.Add("categories_labels", label)
+	}
+	if q.Closed != nil {
+		vals.Set("closed", strconv.FormatBool(*q.Closed))
+	}
+	if q.IncludeChat != nil {
+		vals.Set("include_chat", strconv.FormatBool(*q.IncludeChat))
+	}
+	if q.Recurrence != "" {
+		vals.Set("recurrence", q.Recurrence)
+	}
+
+	var series []*Series
+	if err := c.http.Do(ctx, http.MethodGet, "/series", vals, nil, nil, &series); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// SeriesByID 根据 ID 获取单个系列（含其事件）。
+func (c *RESTClient) SeriesByID(ctx context.Context, id string) (*Series, error) {
+	if id == "" {
+		return nil, ErrInvalidArgument("id is required")
+	}
+	var series Series
+	if err := c.http.Do(ctx, http.MethodGet, "/series/"+url.PathEscape(id), nil, nil, nil, &series); err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// Sports 返回体育联赛元数据（含联赛对应的标签与系列）。
+func (c *RESTClient) Sports(ctx context.Context) ([]*Sport, error) {
+	var sports []*Sport
+	if err := c.http.Do(ctx, http.MethodGet, "/sports", nil, nil, nil, &sports); err != nil {
+		return nil, err
+	}
+	return sports, nil
+}
+
+// TeamsQuery 过滤球队列表。
+type TeamsQuery struct {
+	Limit  int
+	Offset int
+
+	Order     string
+	Ascending bool
+
+	Leagues       []string
+	Names         []string
+	Abbreviations []string
+}
+
+// Teams 返回球队列表。
+func (c *RESTClient) Teams(ctx context.Context, q TeamsQuery) ([]*Team, error) {
+	if q.Limit <= 0 {
+		q.Limit = 100
+	}
+
+	vals := url.Values{}
+	vals.Set("limit", strconv.Itoa(q.Limit))
+	vals.Set("offset", strconv.Itoa(q.Offset))
+	if q.Order != "" {
+		vals.Set("order", q.Order)
+	}
+	vals.Set("ascending", strconv.FormatBool(q.Ascending))
+
+	for _, league := range q.Leagues {
+		vals.Add("league", league)
+	}
+	for _, name := range q.Names {
+		vals.Add("name", name)
+	}
+	for _, abbr := range q.Abbreviations {
+		vals.Add("abbreviation", abbr)
+	}
+
+	var teams []*Team
+	if err := c.http.Do(ctx, http.MethodGet, "/teams", vals, nil, nil, &teams); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
@@ -0,0 +1,139 @@
+// rest_tags.go 模块
+package polymarket
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// TagsQuery 过滤标签列表。
+type TagsQuery struct {
+	Limit  int
+	Offset int
+
+	Order     string
+	Ascending bool
+
+	IncludeTemplate *bool
+	IsCarousel      *bool
+}
+
+// Tags 返回标签列表。
+func (c *RESTClient) Tags(ctx context.Context, q TagsQuery) ([]*Tag, error) {
+	if q.Limit <= 0 {
+		q.Limit = 100
+	}
+
+	vals := url.Values{}
+	vals.Set("limit", strconv.Itoa(q.Limit))
+	vals.Set("offset", strconv.Itoa(q.Offset))
+	if q.Order != "" {
+		vals.Set("order", q.Order)
+	}
+	vals.Set("ascending", strconv.FormatBool(q.Ascending))
+	if q.IncludeTemplate != nil {
+		vals.Set("include_template", strconv.FormatBool(*q.IncludeTemplate))
+	}
+	if q.IsCarousel != nil {
+		vals.Set("is_carousel", strconv.FormatBool(*q.IsCarousel))
+	}
+
+	var tags []*Tag
+	if err := c.http.Do(ctx, http.MethodGet, "/tags", vals, nil, nil, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// TagByID 根据 ID 获取单个标签。
+func (c *RESTClient) TagByID(ctx context.Context, id string) (*Tag, error) {
+	if id == "" {
+		return nil, ErrInvalidArgument("id is required")
+	}
+	var tag Tag
+	if err := c.http.Do(ctx, http.MethodGet, "/tags/"+url.PathEscape(id), nil, nil, nil, &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// TagBySlug 根据 slug 获取单个标签。
+func (c *RESTClient) TagBySlug(ctx context.Context, slug string) (*Tag, error) {
+	if slug == "" {
+		return nil, ErrInvalidArgument("slug is required")
+	}
+	var tag Tag
+	if err := c.http.Do(ctx, http.MethodGet, "/tags/slug/"+url.PathEscape(slug), nil, nil, nil, &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// RelatedTagsQuery 控制相关标签查询。
+type RelatedTagsQuery struct {
+	// OmitEmpty 省略没有活跃市场的标签。
+	OmitEmpty *bool
+	// Status 按关联事件状态过滤：active / closed / all。
+	Status string
+}
+
+// RelatedTagsByID 返回标签的关联关系（含排序）。
+func (c *RESTClient) RelatedTagsByID(ctx context.Context, id string, q RelatedTagsQuery) ([]*RelatedTag, error) {
+	if id == "" {
+		return nil, ErrInvalidArgument("id is required")
+	}
+	var out []*RelatedTag
+	if err := c.http.Do(ctx, http.MethodGet, "/tags/"+url.PathEscape(id)+"/related-tags", q.values(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RelatedTagsBySlug 按 slug 返回标签的关联关系。
+func (c *RESTClient) RelatedTagsBySlug(ctx context.Context, slug string, q RelatedTagsQuery) ([]*RelatedTag, error) {
+	if slug == "" {
+		return nil, ErrInvalidArgument("slug is required")
+	}
+	var out []*RelatedTag
+	if err := c.http.Do(ctx, http.MethodGet, "/tags/slug/"+url.PathEscape(slug)+"/related-tags", q.values(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TagsRelatedToID 返回与标签相关的标签。
+func (c *RESTClient) TagsRelatedToID(ctx context.Context, id string, q RelatedTagsQuery) ([]*Tag, error) {
+	if id == "" {
+		return nil, ErrInvalidArgument("id is required")
+	}
+	var tags []*Tag
+	if err := c.http.Do(ctx, http.MethodGet, "/tags/"+url.PathEscape(id)+"/related-tags/tags", q.values(), nil, nil, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// TagsRelatedToSlug 按 slug 返回相关的标签。
+func (c *RESTClient) TagsRelatedToSlug(ctx context.Context, slug string, q RelatedTagsQuery) ([]*Tag, error) {
+	if slug == "" {
+		return nil, ErrInvalidArgument("slug is required")
+	}
+	var tags []*Tag
+	if err := c.http.Do(ctx, http.MethodGet, "/tags/slug/"+url.PathEscape(slug)+"/related-tags/tags", q.values(), nil, nil, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (q RelatedTagsQuery) values() url.Values {
+	vals := url.Values{}
+	if q.OmitEmpty != nil {
+		vals.Set("omit_empty", strconv.FormatBool(*q.OmitEmpty))
+	}
+	if q.Status != "" {
+		vals.Set("status", q.Status)
+	}
+	return vals
+}
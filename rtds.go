@@ -5,10 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/gorilla/websocket"
+	"github.com/dcsunny/polymarket-sdk/internal/metrics"
 )
 
 const (
@@ -16,12 +18,26 @@ const (
 )
 
 // RTDSClient 处理 Polymarket RTDS 流式传输。
+//
+// 客户端记录所有活跃订阅（含 filters）；连接断开后按 Config.RTDSReconnect 自动重连，
+// 并以最新的认证信息重放这些订阅。因认证失败被服务端关闭时不会重连。
 type RTDSClient struct {
 	cfg Config
 
-	mu       sync.RWMutex
-	conn     *websocket.Conn
-	handlers map[string]RTDSMessageHandler
+	mu            sync.RWMutex
+	conn          *wsConn
+	handlers      map[rtdsKey][]*rtdsHandler
+	sinks         map[*rtdsSink]struct{}
+	subs          []RTDSSubscriptionDetail
+	stateHandlers []WSSConnectionHandler
+
+	// subMu 串行化订阅变更与重连后的重放，避免同一订阅在新连接上重复发送。
+	subMu sync.Mutex
+
+	credentials RTDSCredentialsProvider
+	onError     RTDSErrorHandler
+
+	metrics Metrics
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -31,36 +47,275 @@ func NewRTDSClient(cfg Config) *RTDSClient {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &RTDSClient{
 		cfg:      cfg,
-		handlers: make(map[string]RTDSMessageHandler),
+		handlers: make(map[rtdsKey][]*rtdsHandler),
+		sinks:    make(map[*rtdsSink]struct{}),
+		metrics:  metrics.OrNop(cfg.Metrics),
 		ctx:      ctx,
 		cancel:   cancel,
 	}
 }
 
-// Connect 打开 RTDS 连接。
+// Connect 打开 RTDS 连接并重放已记录的订阅；已连接时替换为新连接。Close 之后可再次 Connect。
 func (c *RTDSClient) Connect() error {
 	if c.cfg.RTDSURL == "" {
 		return errors.New("RTDS URL is required")
 	}
-	conn, _, err := websocket.DefaultDialer.Dial(c.cfg.RTDSURL, nil)
+
+	c.mu.Lock()
+	if c.ctx == nil || c.ctx.Err() != nil {
+		c.ctx, c.cancel = context.WithCancel(context.Background())
+	}
+	ctx := c.ctx
+	c.mu.Unlock()
+
+	c.emitState(WSSConnectionEvent{Channel: MetricsChannelRTDS, State: WSSStateConnecting})
+	conn, err := c.dial(ctx)
 	if err != nil {
+		c.emitState(WSSConnectionEvent{Channel: MetricsChannelRTDS, State: WSSStateDisconnected, Err: err})
 		return err
 	}
 
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
 	c.mu.Lock()
 	if c.conn != nil {
-		_ = c.conn.Close()
+		_ = c.conn.close()
+		c.metrics.IncReconnect(MetricsChannelRTDS)
 	}
 	c.conn = conn
 	c.mu.Unlock()
 
-	go c.readLoop()
-	go c.pingLoop()
-	return nil
+	c.emitState(WSSConnectionEvent{Channel: MetricsChannelRTDS, State: WSSStateConnected})
+	go c.readLoop(ctx, conn)
+	go c.pingLoop(ctx, conn)
+	return c.replay()
+}
+
+func (c *RTDSClient) dial(ctx context.Context) (*wsConn, error) {
+	dialer, header, err := c.cfg.wsDialer()
+	if err != nil {
+		return nil, err
+	}
+	ws, _, err := dialer.DialContext(ctx, c.cfg.RTDSURL, header)
+	if err != nil {
+		return nil, err
+	}
+	return newWSConn(ws, staleTimeout(c.cfg.WSStaleTimeout, DefaultRTDSStaleTimeout)), nil
+}
+
+// OnConnectionState 注册连接状态处理器（事件的 Channel 为 "rtds"）；处理器在内部 goroutine 中同步调用，不应阻塞。
+func (c *RTDSClient) OnConnectionState(handler WSSConnectionHandler) {
+	if handler == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stateHandlers = append(c.stateHandlers, handler)
+}
+
+// Subscriptions 返回当前记录的订阅（不含认证信息）。
+func (c *RTDSClient) Subscriptions() []RTDSSubscriptionDetail {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]RTDSSubscriptionDetail(nil), c.subs...)
+}
+
+// rtdsKey 标识处理器订阅的 topic/type；type 为 RTDSTypeAll 时匹配该 topic 的全部类型。
+type rtdsKey struct {
+	topic string
+	typ   string
+}
+
+// rtdsHandler 包装处理器，使 On 返回的移除函数可以按身份删除。
+type rtdsHandler struct {
+	fn RTDSMessageHandler
+	// owners 是注册该处理器的订阅（rtdsSubKey）；为空表示经 On 注册，不随 Unsubscribe 移除。
+	owners map[string]bool
+}
+
+// Subscribe 订阅任意 topic/type（msgType 为空时等同 RTDSTypeAll），filters 原样放入订阅消息。
+// handler 可为 nil（只订阅，例如配合 Stream 使用）。
+//
+// 每次调用都会注册 handler；相同 topic/type/filters 的订阅只向服务端发送一次。
+// 处理器按 topic/type 分发，RTDS 消息不携带 filters，因此同一 topic/type 以不同 filters 多次订阅时，
+// 每个处理器都会收到所有这些订阅的消息，需自行按内容过滤（SubscribeCryptoPrices 已按 symbols 过滤）。
+func (c *RTDSClient) Subscribe(topic, msgType string, filters interface{}, handler RTDSMessageHandler) error {
+	if msgType == "" {
+		msgType = RTDSTypeAll
+	}
+	return c.subscribe([]RTDSSubscriptionDetail{{Topic: topic, Type: msgType, Filters: filters}}, handler)
+}
+
+// On 为 topic/type 追加处理器，不发送订阅消息；返回的函数用于移除该处理器（可重复调用）。
+// 经 On 注册的处理器不受 Unsubscribe 影响。
+func (c *RTDSClient) On(topic, msgType string, handler RTDSMessageHandler) (off func()) {
+	if handler == nil {
+		return func() {}
+	}
+	if msgType == "" {
+		msgType = RTDSTypeAll
+	}
+	k := rtdsKey{topic: topic, typ: msgType}
+	h := &rtdsHandler{fn: handler}
+	c.mu.Lock()
+	c.handlers[k] = append(c.handlers[k], h)
+	c.mu.Unlock()
+	return func() { c.off(k, h) }
+}
+
+func (c *RTDSClient) off(k rtdsKey, h *rtdsHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	list := c.handlers[k]
+	for i, v := range list {
+		if v == h {
+			c.handlers[k] = append(list[:i:i], list[i+1:]...)
+			break
+		}
+	}
+	if len(c.handlers[k]) == 0 {
+		delete(c.handlers, k)
+	}
+}
+
+// subscribe 记录订阅、注册 handler，并在一条消息中发送新增的订阅项。
+// 相同 topic/type/filters 的订阅只发送一次；未连接时只记录，在 Connect 时发送。
+func (c *RTDSClient) subscribe(details []RTDSSubscriptionDetail, handler RTDSMessageHandler) error {
+	authed, err := c.authorize(details)
+	if err != nil {
+		return err
+	}
+
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+
+	c.mu.Lock()
+	var fresh []RTDSSubscriptionDetail
+	for i, d := range details {
+		if c.tracked(d) {
+			continue
+		}
+		c.subs = append(c.subs, d)
+		fresh = append(fresh, authed[i])
+	}
+	c.mu.Unlock()
+	if handler != nil {
+		// 同一 topic/type 只注册一次，处理器归属于对应的订阅，随其 Unsubscribe 移除。
+		owned := make(map[rtdsKey]*rtdsHandler, len(details))
+		c.mu.Lock()
+		for _, d := range details {
+			k := rtdsKey{topic: d.Topic, typ: d.Type}
+			h := owned[k]
+			if h == nil {
+				h = &rtdsHandler{fn: handler, owners: make(map[string]bool)}
+				owned[k] = h
+				c.handlers[k] = append(c.handlers[k], h)
+			}
+			h.owners[rtdsSubKey(d)] = true
+		}
+		c.mu.Unlock()
+	}
+	if len(fresh) == 0 {
+		return nil
+	}
+	return c.sendIfConnected(RTDSSubscription{Action: "subscribe", Subscriptions: fresh})
+}
+
+// tracked 判断订阅是否已记录；调用方需持有 mu。
+func (c *RTDSClient) tracked(d RTDSSubscriptionDetail) bool {
+	key := rtdsSubKey(d)
+	for _, s := range c.subs {
+		if rtdsSubKey(s) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func rtdsSubKey(d RTDSSubscriptionDetail) string {
+	filters, _ := json.Marshal(d.Filters)
+	return d.Topic + "|" + d.Type + "|" + string(filters)
+}
+
+// replay 在新连接上以最新认证信息重放全部订阅；调用方需持有 subMu。
+func (c *RTDSClient) replay() error {
+	c.mu.RLock()
+	subs := append([]RTDSSubscriptionDetail(nil), c.subs...)
+	c.mu.RUnlock()
+	if len(subs) == 0 {
+		return nil
+	}
+	authed, err := c.authorize(subs)
+	if err != nil {
+		return err
+	}
+	return c.send(RTDSSubscription{Action: "subscribe", Subscriptions: authed})
 }
 
-// SubscribeCryptoPrices 订阅加密货币价格更新。
+// SubscribeCryptoPrices 订阅加密货币价格更新。symbols 非空时 handler 只收到这些交易对的消息
+// （同一来源以不同 symbols 多次订阅时，服务端推送的是所有订阅的并集）。
 func (c *RTDSClient) SubscribeCryptoPrices(source CryptoPriceSource, symbols []string, handler RTDSMessageHandler) error {
+	sub, err := cryptoPriceSubscription(source, symbols)
+	if err != nil {
+		return err
+	}
+	return c.subscribe(sub.Subscriptions, symbolFilter(symbols, handler))
+}
+
+// symbolFilter 只把 symbols 中交易对的价格消息交给 handler；symbols 为空时原样返回。
+func symbolFilter(symbols []string, handler RTDSMessageHandler) RTDSMessageHandler {
+	if handler == nil || len(symbols) == 0 {
+		return handler
+	}
+	wanted := make(map[string]bool, len(symbols))
+	for _, s := range symbols {
+		wanted[strings.ToLower(s)] = true
+	}
+	return func(msg *RTDSMessage) error {
+		var p RTDSCryptoPricePayload
+		if err := json.Unmarshal(msg.Payload, &p); err == nil && !wanted[strings.ToLower(p.Symbol)] {
+			return nil
+		}
+		return handler(msg)
+	}
+}
+
+// StreamCryptoPrices 订阅加密货币价格更新并返回消息流；ctx 结束时流关闭。
+// 同一条消息会被推送给所有匹配的流，消费者不应修改它。
+func (c *RTDSClient) StreamCryptoPrices(ctx context.Context, source CryptoPriceSource, symbols []string, opts StreamOptions) (*Stream[*RTDSMessage], error) {
+	sub, err := cryptoPriceSubscription(source, symbols)
+	if err != nil {
+		return nil, err
+	}
+	s := c.Stream(ctx, string(source), opts)
+	if err := c.subscribe(sub.Subscriptions, nil); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Stream 返回 topic 的消息流（不发送订阅消息）。
+func (c *RTDSClient) Stream(ctx context.Context, topic string, opts StreamOptions) *Stream[*RTDSMessage] {
+	k := &rtdsSink{topic: topic}
+	k.stream = newStream[*RTDSMessage](ctx, MetricsChannelRTDS, c.metrics, opts)
+	k.stream.onClose = func() {
+		c.mu.Lock()
+		delete(c.sinks, k)
+		c.mu.Unlock()
+	}
+	c.mu.Lock()
+	c.sinks[k] = struct{}{}
+	c.mu.Unlock()
+	return k.stream
+}
+
+type rtdsSink struct {
+	topic  string
+	stream *Stream[*RTDSMessage]
+}
+
+func cryptoPriceSubscription(source CryptoPriceSource, symbols []string) (RTDSSubscription, error) {
 	var filters interface{}
 	switch source {
 	case CryptoPriceSourceBinance:
@@ -72,54 +327,118 @@ func (c *RTDSClient) SubscribeCryptoPrices(source CryptoPriceSource, symbols []s
 			filters = map[string]interface{}{"symbols": symbols}
 		}
 	default:
-		return errors.New("unsupported price source")
+		return RTDSSubscription{}, errors.New("unsupported price source")
 	}
 
-	sub := RTDSSubscription{
+	return RTDSSubscription{
 		Action: "subscribe",
 		Subscriptions: []RTDSSubscriptionDetail{
 			{
 				Topic:   string(source),
-				Type:    "update",
+				Type:    RTDSTypeUpdate,
 				Filters: filters,
 			},
 		},
-	}
-
-	c.mu.Lock()
-	c.handlers[string(source)] = handler
-	c.mu.Unlock()
-
-	return c.send(sub)
+	}, nil
 }
 
-// Unsubscribe 移除主题订阅。
+// Unsubscribe 移除主题的全部订阅及随这些订阅注册的处理器（经 On 注册的处理器保留）。
 func (c *RTDSClient) Unsubscribe(topic string) error {
-	sub := RTDSSubscription{
-		Action: "unsubscribe",
-		Subscriptions: []RTDSSubscriptionDetail{
-			{Topic: topic},
-		},
+	return c.unsubscribe(topic, "")
+}
+
+// UnsubscribeType 只移除 topic/type 的订阅及其处理器（msgType 为 RTDSTypeAll 时只移除通配订阅）。
+func (c *RTDSClient) UnsubscribeType(topic, msgType string) error {
+	if msgType == "" {
+		msgType = RTDSTypeAll
 	}
+	return c.unsubscribe(topic, msgType)
+}
+
+// unsubscribe msgType 为空时匹配 topic 下的全部类型；取消订阅消息携带被移除订阅的 type 与 filters，
+// 与当初发送的订阅一一对应。没有匹配的订阅时不发送。
+func (c *RTDSClient) unsubscribe(topic, msgType string) error {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
 
 	c.mu.Lock()
-	delete(c.handlers, topic)
+	var removed []RTDSSubscriptionDetail
+	keys := make(map[string]bool)
+	kept := c.subs[:0]
+	for _, d := range c.subs {
+		if d.Topic == topic && (msgType == "" || d.Type == msgType) {
+			removed = append(removed, d)
+			keys[rtdsSubKey(d)] = true
+			continue
+		}
+		kept = append(kept, d)
+	}
+	c.subs = kept
+	for k, list := range c.handlers {
+		if k.topic != topic {
+			continue
+		}
+		var left []*rtdsHandler
+		for _, h := range list {
+			if h.owners != nil {
+				for key := range keys {
+					delete(h.owners, key)
+				}
+				if len(h.owners) == 0 {
+					continue
+				}
+			}
+			left = append(left, h)
+		}
+		if len(left) == 0 {
+			delete(c.handlers, k)
+		} else {
+			c.handlers[k] = left
+		}
+	}
 	c.mu.Unlock()
 
-	return c.send(sub)
+	if len(removed) == 0 {
+		return nil
+	}
+	if authed, err := c.authorize(removed); err == nil {
+		removed = authed
+	}
+	return c.sendIfConnected(RTDSSubscription{Action: "unsubscribe", Subscriptions: removed})
 }
 
-// Close 关闭 RTDS 客户端。
+// Close 停止重连并优雅关闭 RTDS 连接（写完已排队的消息、发送 close 帧并等待对端确认）；
+// 订阅记录会被清空，流式订阅随之结束。
 func (c *RTDSClient) Close() error {
-	c.cancel()
 	c.mu.Lock()
-	defer c.mu.Unlock()
-	if c.conn == nil {
-		return nil
+	if c.cancel != nil {
+		c.cancel()
 	}
-	err := c.conn.Close()
+	c.subs = nil
+	sinks := c.sinks
+	c.sinks = make(map[*rtdsSink]struct{})
+	conn := c.conn
 	c.conn = nil
-	return err
+	c.mu.Unlock()
+
+	for k := range sinks {
+		k.stream.Close()
+	}
+	if conn == nil {
+		return nil
+	}
+	return conn.shutdown()
+}
+
+// sendIfConnected 未连接时直接返回（订阅已记录，连接后重放）。
+func (c *RTDSClient) sendIfConnected(msg interface{}) error {
+	c.mu.RLock()
+	conn := c.conn
+	c.mu.RUnlock()
+	if conn == nil {
+		return nil
+	}
+	return c.send(msg)
 }
 
 func (c *RTDSClient) send(msg interface{}) error {
@@ -133,58 +452,163 @@ func (c *RTDSClient) send(msg interface{}) error {
 	if err != nil {
 		return err
 	}
-	return conn.WriteMessage(websocket.TextMessage, data)
+	return conn.writeText(data)
 }
 
-func (c *RTDSClient) readLoop() {
+// readLoop 读取消息直到连接断开（包括静默超时被强制断开），随后交给 handleDisconnect。
+func (c *RTDSClient) readLoop(ctx context.Context, conn *wsConn) {
 	for {
-		select {
-		case <-c.ctx.Done():
+		message, err := conn.read()
+		if err != nil {
+			c.handleDisconnect(ctx, conn, err)
 			return
-		default:
+		}
+
+		if len(message) == 0 || string(message) == "PING" || string(message) == "PONG" {
+			continue
+		}
+		var msg RTDSMessage
+		if err := json.Unmarshal(message, &msg); err != nil {
+			c.reportError("", fmt.Errorf("decode message: %w", err))
+			continue
+		}
+		if msg.Topic == "" {
+			c.handleNotice(message)
+			continue
 		}
 
 		c.mu.RLock()
-		conn := c.conn
+		var handlers []*rtdsHandler
+		handlers = append(handlers, c.handlers[rtdsKey{topic: msg.Topic, typ: msg.Type}]...)
+		if msg.Type != RTDSTypeAll {
+			handlers = append(handlers, c.handlers[rtdsKey{topic: msg.Topic, typ: RTDSTypeAll}]...)
+		}
+		var sinks []*rtdsSink
+		for k := range c.sinks {
+			if k.topic == msg.Topic {
+				sinks = append(sinks, k)
+			}
+		}
 		c.mu.RUnlock()
-		if conn == nil {
-			time.Sleep(time.Second)
-			continue
+		c.metrics.IncWSSMessage(MetricsChannelRTDS, msg.Topic)
+		if len(handlers) > 0 {
+			start := time.Now()
+			for _, h := range handlers {
+				if err := h.fn(&msg); err != nil {
+					c.reportError(msg.Topic, err)
+				}
+			}
+			c.metrics.ObserveHandler(MetricsChannelRTDS, msg.Topic, time.Since(start))
 		}
+		for _, k := range sinks {
+			k.stream.push(&msg)
+		}
+	}
+}
 
-		_, message, err := conn.ReadMessage()
-		if err != nil {
+// handleDisconnect 处理读循环退出：主动关闭或连接已被替换时直接返回；认证失败不重连，否则进入重连。
+func (c *RTDSClient) handleDisconnect(ctx context.Context, conn *wsConn, cause error) {
+	_ = conn.close()
+	if ctx.Err() != nil {
+		return
+	}
+	c.mu.RLock()
+	current := c.conn
+	c.mu.RUnlock()
+	if current != conn {
+		return
+	}
+
+	if authErr := closeError(cause); authErr != nil {
+		c.reportError("", authErr)
+		c.emitState(WSSConnectionEvent{Channel: MetricsChannelRTDS, State: WSSStateDisconnected, Err: authErr})
+		return
+	}
+	c.emitState(WSSConnectionEvent{Channel: MetricsChannelRTDS, State: WSSStateDisconnected, Err: cause})
+	if c.cfg.RTDSReconnect.Disabled {
+		return
+	}
+	go c.reconnect(ctx, conn)
+}
+
+// reconnect 按退避策略重连并重放订阅；期间若连接被手动替换或客户端关闭则放弃。
+func (c *RTDSClient) reconnect(ctx context.Context, old *wsConn) {
+	policy := c.cfg.RTDSReconnect
+	for attempt := 1; ; attempt++ {
+		if policy.exhausted(attempt) {
+			c.emitState(WSSConnectionEvent{Channel: MetricsChannelRTDS, State: WSSStateDisconnected, Attempt: attempt - 1, Err: ErrReconnectExhausted})
 			return
 		}
-
-		var msg RTDSMessage
-		if err := json.Unmarshal(message, &msg); err != nil {
-			continue
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(policy.backoff(attempt)):
 		}
 
 		c.mu.RLock()
-		handler := c.handlers[msg.Topic]
+		current := c.conn
 		c.mu.RUnlock()
-		if handler != nil {
-			_ = handler(&msg)
+		if current != old {
+			return
+		}
+
+		c.emitState(WSSConnectionEvent{Channel: MetricsChannelRTDS, State: WSSStateConnecting, Attempt: attempt})
+		conn, err := c.dial(ctx)
+		if err != nil {
+			c.emitState(WSSConnectionEvent{Channel: MetricsChannelRTDS, State: WSSStateDisconnected, Attempt: attempt, Err: err})
+			continue
+		}
+
+		c.subMu.Lock()
+		c.mu.Lock()
+		if c.conn != old || ctx.Err() != nil {
+			c.mu.Unlock()
+			c.subMu.Unlock()
+			_ = conn.close()
+			return
 		}
+		c.conn = conn
+		c.mu.Unlock()
+
+		c.metrics.IncReconnect(MetricsChannelRTDS)
+		c.emitState(WSSConnectionEvent{Channel: MetricsChannelRTDS, State: WSSStateConnected, Attempt: attempt})
+		go c.readLoop(ctx, conn)
+		go c.pingLoop(ctx, conn)
+		err = c.replay()
+		c.subMu.Unlock()
+		if err != nil {
+			// 认证信息获取失败时报告错误；写失败说明新连接也已断开，读循环会再次触发重连。
+			c.reportError("", err)
+			return
+		}
+		c.emitState(WSSConnectionEvent{Channel: MetricsChannelRTDS, State: WSSStateResubscribed, Attempt: attempt})
+		return
+	}
+}
+
+func (c *RTDSClient) emitState(event WSSConnectionEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	c.mu.RLock()
+	handlers := append([]WSSConnectionHandler(nil), c.stateHandlers...)
+	c.mu.RUnlock()
+	for _, h := range handlers {
+		h(event)
 	}
 }
 
-func (c *RTDSClient) pingLoop() {
+func (c *RTDSClient) pingLoop(ctx context.Context, conn *wsConn) {
 	ticker := time.NewTicker(rtdsPingInterval)
 	defer ticker.Stop()
 	for {
 		select {
-		case <-c.ctx.Done():
+		case <-ctx.Done():
+			return
+		case <-conn.stop:
 			return
 		case <-ticker.C:
-			c.mu.RLock()
-			conn := c.conn
-			c.mu.RUnlock()
-			if conn != nil {
-				_ = conn.WriteMessage(websocket.TextMessage, []byte("PING"))
-			}
+			conn.ping()
 		}
 	}
 }
@@ -0,0 +1,167 @@
+// rtds_auth.go 模块
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+)
+
+// RTDSCredentials 是私有 RTDS 主题使用的认证信息。
+type RTDSCredentials struct {
+	// Clob 用于 clob_user 等私有主题。
+	Clob *RTDSClobAuth
+	// Gamma 用于 comments 主题。
+	Gamma *RTDSGammaAuth
+}
+
+// RTDSCredentialsProvider 在每次发送订阅（包括重连后的重放）时提供认证信息，便于轮换凭证。
+type RTDSCredentialsProvider func(ctx context.Context) (RTDSCredentials, error)
+
+// RTDSErrorHandler 接收 RTDS 的错误：服务端错误通知、认证失败与处理器返回的错误（topic 可能为空）。
+type RTDSErrorHandler func(topic string, err error)
+
+// RTDSError 表示 RTDS 服务端返回的错误通知或因认证失败关闭连接。
+type RTDSError struct {
+	Topic      string
+	StatusCode int
+	Message    string
+	// Kind 为推断出的类别（如 ErrUnauthorized）；无法识别时为 nil。
+	Kind error
+}
+
+func (e *RTDSError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("rtds error: status=%d msg=%s", e.StatusCode, e.Message)
+	}
+	return "rtds error: msg=" + e.Message
+}
+
+// Unwrap 返回错误类别，便于 errors.Is 匹配。
+func (e *RTDSError) Unwrap() error {
+	return e.Kind
+}
+
+// SetCredentialsProvider 设置认证信息提供者；未设置时使用 Config 中的 API 凭证与 Address。
+func (c *RTDSClient) SetCredentialsProvider(provider RTDSCredentialsProvider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.credentials = provider
+}
+
+// OnError 注册错误处理器。认证失败通常在订阅发送之后才由服务端通知，因此通过该处理器返回。
+func (c *RTDSClient) OnError(handler RTDSErrorHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onError = handler
+}
+
+// SubscribeClobUserOrders 订阅当前账户的订单事件（clob_user/order，需要 API 凭证）。
+func (c *RTDSClient) SubscribeClobUserOrders(handler func(*WSSOrderEvent) error) error {
+	return c.subscribeTopic(RTDSTopicClobUser, []string{RTDSTypeOrder}, nil, payloadHandler(handler))
+}
+
+// SubscribeClobUserTrades 订阅当前账户的成交事件（clob_user/trade，需要 API 凭证）。
+func (c *RTDSClient) SubscribeClobUserTrades(handler func(*WSSTradeEvent) error) error {
+	return c.subscribeTopic(RTDSTopicClobUser, []string{RTDSTypeTrade}, nil, payloadHandler(handler))
+}
+
+// authorize 为需要认证的主题附加认证信息，返回副本；clob_user 缺少凭证时返回错误。
+func (c *RTDSClient) authorize(details []RTDSSubscriptionDetail) ([]RTDSSubscriptionDetail, error) {
+	needed := false
+	for _, d := range details {
+		if d.Topic == RTDSTopicClobUser || d.Topic == RTDSTopicComments {
+			needed = true
+		}
+	}
+	if !needed {
+		return details, nil
+	}
+	creds, err := c.resolveCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("rtds credentials: %w", err)
+	}
+	out := make([]RTDSSubscriptionDetail, len(details))
+	for i, d := range details {
+		switch d.Topic {
+		case RTDSTopicClobUser:
+			if creds.Clob == nil {
+				return nil, errors.New("missing API credentials for clob_user topic")
+			}
+			d.ClobAuth = creds.Clob
+		case RTDSTopicComments:
+			d.GammaAuth = creds.Gamma
+		}
+		out[i] = d
+	}
+	return out, nil
+}
+
+func (c *RTDSClient) resolveCredentials() (RTDSCredentials, error) {
+	c.mu.RLock()
+	provider := c.credentials
+	ctx := c.ctx
+	c.mu.RUnlock()
+	if provider != nil {
+		return provider(ctx)
+	}
+	var creds RTDSCredentials
+	if c.cfg.APIKey != "" && c.cfg.APISecret != "" && c.cfg.Passphrase != "" {
+		creds.Clob = &RTDSClobAuth{Key: c.cfg.APIKey, Secret: c.cfg.APISecret, Passphrase: c.cfg.Passphrase}
+	}
+	if c.cfg.Address != "" {
+		creds.Gamma = &RTDSGammaAuth{Address: c.cfg.Address}
+	}
+	return creds, nil
+}
+
+// handleNotice 处理没有 topic 的服务端消息（错误通知等）。
+func (c *RTDSClient) handleNotice(data []byte) {
+	var notice struct {
+		StatusCode int    `json:"statusCode"`
+		Message    string `json:"message"`
+		Error      string `json:"error"`
+		Body       struct {
+			Message string `json:"message"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(data, &notice); err != nil {
+		return
+	}
+	msg := notice.Error
+	if msg == "" {
+		msg = notice.Message
+	}
+	if msg == "" {
+		msg = notice.Body.Message
+	}
+	if msg == "" && notice.StatusCode < 400 {
+		return
+	}
+	c.reportError("", &RTDSError{
+		StatusCode: notice.StatusCode,
+		Message:    msg,
+		Kind:       ClassifyError(notice.StatusCode, "", msg),
+	})
+}
+
+// closeError 把认证失败导致的关闭转换为 RTDSError；其他错误返回 nil。
+func closeError(err error) error {
+	var ce *websocket.CloseError
+	if !errors.As(err, &ce) || ce.Code != websocket.ClosePolicyViolation {
+		return nil
+	}
+	return &RTDSError{Message: ce.Text, Kind: ErrUnauthorized}
+}
+
+func (c *RTDSClient) reportError(topic string, err error) {
+	c.mu.RLock()
+	h := c.onError
+	c.mu.RUnlock()
+	if h != nil {
+		h(topic, err)
+	}
+}
@@ -0,0 +1,285 @@
+// rtds_test.go 模块
+package polymarket_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pm "github.com/dcsunny/polymarket-sdk"
+	"github.com/dcsunny/polymarket-sdk/polymarkettest"
+	"github.com/gorilla/websocket"
+)
+
+// recorder 记录处理器收到的价格消息（symbol:value）。
+type recorder struct {
+	mu  sync.Mutex
+	got []string
+}
+
+func (r *recorder) handler(msg *pm.RTDSMessage) error {
+	var p pm.RTDSCryptoPricePayload
+	if err := json.Unmarshal(msg.Payload, &p); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.got = append(r.got, p.Symbol+":"+strconvFloat(p.Value))
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *recorder) count(v string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, g := range r.got {
+		if g == v {
+			n++
+		}
+	}
+	return n
+}
+
+func strconvFloat(v float64) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func publishPrice(srv *polymarkettest.Server, symbol string, value float64) {
+	srv.PublishRTDS(string(pm.CryptoPriceSourceBinance), pm.RTDSTypeUpdate,
+		pm.RTDSCryptoPricePayload{Symbol: symbol, Value: value, Timestamp: time.Now().UnixMilli()})
+}
+
+// syncRTDS 反复发布 marker 直到 r 收到，确保服务端已处理订阅且之前的消息都已分发。
+func syncRTDS(t *testing.T, srv *polymarkettest.Server, r *recorder, symbol string, marker float64) {
+	t.Helper()
+	want := symbol + ":" + strconvFloat(marker)
+	deadline := time.Now().Add(5 * time.Second)
+	for r.count(want) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for %s", want)
+		}
+		publishPrice(srv, symbol, marker)
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRTDSHandlersAndFilters(t *testing.T) {
+	srv := polymarkettest.NewServer(polymarkettest.Options{})
+	defer srv.Close()
+	sdk, err := pm.New(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sdk.RTDS.Close()
+
+	btc, btc2, eth, all, extra := &recorder{}, &recorder{}, &recorder{}, &recorder{}, &recorder{}
+	subs := []struct {
+		symbols []string
+		r       *recorder
+	}{
+		{[]string{"btcusdt"}, btc},
+		{[]string{"btcusdt"}, btc2}, // 相同订阅的第二个独立处理器
+		{[]string{"ethusdt"}, eth},
+		{nil, all},
+	}
+	for _, sub := range subs {
+		if err := sdk.RTDS.SubscribeCryptoPrices(pm.CryptoPriceSourceBinance, sub.symbols, sub.r.handler); err != nil {
+			t.Fatal(err)
+		}
+	}
+	off := sdk.RTDS.On(string(pm.CryptoPriceSourceBinance), pm.RTDSTypeUpdate, extra.handler)
+	if got := len(sdk.RTDS.Subscriptions()); got != 3 {
+		t.Fatalf("subscriptions = %d, want 3 (wire subscriptions deduplicated)", got)
+	}
+	if err := sdk.RTDS.Connect(); err != nil {
+		t.Fatal(err)
+	}
+
+	syncRTDS(t, srv, btc, "btcusdt", -1)
+	publishPrice(srv, "btcusdt", 100)
+	publishPrice(srv, "ethusdt", 200)
+	syncRTDS(t, srv, eth, "ethusdt", -2)
+
+	tests := []struct {
+		name string
+		r    *recorder
+		btc  int
+		eth  int
+	}{
+		{"btc", btc, 1, 0},
+		{"second btc handler", btc2, 1, 0},
+		{"eth", eth, 0, 1},
+		{"all symbols", all, 1, 1},
+		{"On", extra, 1, 1},
+	}
+	for _, tt := range tests {
+		if n := tt.r.count("btcusdt:100"); n != tt.btc {
+			t.Errorf("%s handler got btc tick %d times, want %d", tt.name, n, tt.btc)
+		}
+		if n := tt.r.count("ethusdt:200"); n != tt.eth {
+			t.Errorf("%s handler got eth tick %d times, want %d", tt.name, n, tt.eth)
+		}
+	}
+
+	off()
+	off()
+	publishPrice(srv, "btcusdt", 300)
+	syncRTDS(t, srv, btc, "btcusdt", -3)
+	if n := extra.count("btcusdt:300"); n != 0 {
+		t.Errorf("removed handler still called %d times", n)
+	}
+}
+
+func TestRTDSReportsUndecodableFrames(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	ws := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.WriteMessage(websocket.TextMessage, []byte("PONG"))
+		_ = conn.WriteMessage(websocket.TextMessage, []byte("{not json"))
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer ws.Close()
+
+	c := pm.NewRTDSClient(pm.Config{RTDSURL: "ws" + strings.TrimPrefix(ws.URL, "http")})
+	defer c.Close()
+	errs := make(chan error, 4)
+	c.OnError(func(_ string, err error) { errs <- err })
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "decode message") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("decode error not reported")
+	}
+	select {
+	case err := <-errs:
+		t.Fatalf("unexpected extra error (PONG should be ignored): %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// rtdsRecorder 是记录客户端订阅消息并可主动推送消息的 RTDS 服务端。
+type rtdsRecorder struct {
+	*httptest.Server
+	frames chan pm.RTDSSubscription
+	push   chan pm.RTDSMessage
+}
+
+func newRTDSRecorder(t *testing.T) *rtdsRecorder {
+	r := &rtdsRecorder{frames: make(chan pm.RTDSSubscription, 16), push: make(chan pm.RTDSMessage, 16)}
+	upgrader := websocket.Upgrader{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		go func() {
+			for m := range r.push {
+				if conn.WriteJSON(m) != nil {
+					return
+				}
+			}
+		}()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var sub pm.RTDSSubscription
+			if json.Unmarshal(data, &sub) == nil && sub.Action != "" {
+				r.frames <- sub
+			}
+		}
+	}))
+	t.Cleanup(func() {
+		r.Close()
+		close(r.push)
+	})
+	return r
+}
+
+func (r *rtdsRecorder) next(t *testing.T) pm.RTDSSubscription {
+	t.Helper()
+	select {
+	case f := <-r.frames:
+		return f
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for subscription frame")
+		return pm.RTDSSubscription{}
+	}
+}
+
+func TestRTDSUnsubscribe(t *testing.T) {
+	srv := newRTDSRecorder(t)
+	c := pm.NewRTDSClient(pm.Config{RTDSURL: "ws" + strings.TrimPrefix(srv.URL, "http")})
+	defer c.Close()
+
+	topic := string(pm.CryptoPriceSourceBinance)
+	owned, other := &recorder{}, &recorder{}
+	if err := c.SubscribeCryptoPrices(pm.CryptoPriceSourceBinance, []string{"btcusdt"}, owned.handler); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Subscribe("activity", "trades", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	c.On(topic, pm.RTDSTypeUpdate, other.handler)
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if f := srv.next(t); f.Action != "subscribe" || len(f.Subscriptions) != 2 {
+		t.Fatalf("initial frame = %+v", f)
+	}
+
+	if err := c.Unsubscribe(topic); err != nil {
+		t.Fatal(err)
+	}
+	f := srv.next(t)
+	want := pm.RTDSSubscriptionDetail{Topic: topic, Type: pm.RTDSTypeUpdate, Filters: "btcusdt"}
+	if f.Action != "unsubscribe" || len(f.Subscriptions) != 1 || f.Subscriptions[0] != want {
+		t.Fatalf("unsubscribe frame = %+v, want %+v", f, want)
+	}
+	if subs := c.Subscriptions(); len(subs) != 1 || subs[0].Topic != "activity" {
+		t.Fatalf("remaining subscriptions = %+v", subs)
+	}
+
+	// 没有匹配的订阅时不发送取消订阅消息。
+	if err := c.UnsubscribeType("activity", "orders_matched"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case f := <-srv.frames:
+		t.Fatalf("unexpected frame %+v", f)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	payload, _ := json.Marshal(pm.RTDSCryptoPricePayload{Symbol: "btcusdt", Value: 1})
+	srv.push <- pm.RTDSMessage{Topic: topic, Type: pm.RTDSTypeUpdate, Payload: payload}
+	deadline := time.Now().Add(5 * time.Second)
+	for other.count("btcusdt:1") == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("handler registered with On was removed by Unsubscribe")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := owned.count("btcusdt:1"); n != 0 {
+		t.Fatalf("unsubscribed handler called %d times", n)
+	}
+}
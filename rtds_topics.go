@@ -0,0 +1,118 @@
+// rtds_topics.go 模块
+package polymarket
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RTDSHandler 把类型化处理器适配为 RTDSMessageHandler：payload 解码为 T 后连同原始消息一起传入。
+func RTDSHandler[T any](fn func(msg *RTDSMessage, payload *T) error) RTDSMessageHandler {
+	return func(msg *RTDSMessage) error {
+		var v T
+		if err := json.Unmarshal(msg.Payload, &v); err != nil {
+			return fmt.Errorf("decode %s/%s payload: %w", msg.Topic, msg.Type, err)
+		}
+		return fn(msg, &v)
+	}
+}
+
+// payloadHandler 适配只关心 payload 的处理器。
+func payloadHandler[T any](fn func(*T) error) RTDSMessageHandler {
+	return RTDSHandler(func(_ *RTDSMessage, v *T) error { return fn(v) })
+}
+
+// SubscribeActivityTrades 订阅成交动态（activity/trades）。
+func (c *RTDSClient) SubscribeActivityTrades(filter RTDSActivityFilter, handler func(*RTDSActivityTrade) error) error {
+	return c.subscribeTopic(RTDSTopicActivity, []string{RTDSTypeTrades}, filter, payloadHandler(handler))
+}
+
+// SubscribeOrdersMatched 订阅撮合动态（activity/orders_matched）。
+func (c *RTDSClient) SubscribeOrdersMatched(filter RTDSActivityFilter, handler func(*RTDSActivityTrade) error) error {
+	return c.subscribeTopic(RTDSTopicActivity, []string{RTDSTypeOrdersMatched}, filter, payloadHandler(handler))
+}
+
+// SubscribeComments 订阅评论的创建与删除（msg.Type 区分 comment_created / comment_removed）。
+func (c *RTDSClient) SubscribeComments(filter RTDSCommentFilter, handler func(*RTDSMessage, *RTDSComment) error) error {
+	return c.subscribeTopic(RTDSTopicComments, []string{RTDSTypeCommentCreated, RTDSTypeCommentRemoved}, filter, RTDSHandler(handler))
+}
+
+// SubscribeReactions 订阅评论回应的创建与删除（msg.Type 区分 reaction_created / reaction_removed）。
+func (c *RTDSClient) SubscribeReactions(filter RTDSCommentFilter, handler func(*RTDSMessage, *RTDSReaction) error) error {
+	return c.subscribeTopic(RTDSTopicComments, []string{RTDSTypeReactionCreated, RTDSTypeReactionRemoved}, filter, RTDSHandler(handler))
+}
+
+// SubscribeRFQRequests 订阅 RFQ 请求事件（request_created / edited / canceled / expired）。
+func (c *RTDSClient) SubscribeRFQRequests(handler func(*RTDSMessage, *RTDSRFQRequest) error) error {
+	types := []string{RTDSTypeRequestCreated, RTDSTypeRequestEdited, RTDSTypeRequestCanceled, RTDSTypeRequestExpired}
+	return c.subscribeTopic(RTDSTopicRFQ, types, nil, RTDSHandler(handler))
+}
+
+// SubscribeRFQQuotes 订阅 RFQ 报价事件（quote_created / edited / canceled / expired）。
+func (c *RTDSClient) SubscribeRFQQuotes(handler func(*RTDSMessage, *RTDSRFQQuote) error) error {
+	types := []string{RTDSTypeQuoteCreated, RTDSTypeQuoteEdited, RTDSTypeQuoteCanceled, RTDSTypeQuoteExpired}
+	return c.subscribeTopic(RTDSTopicRFQ, types, nil, RTDSHandler(handler))
+}
+
+// SubscribeClobPriceChanges 订阅资产的价格档位变化（clob_market/price_change）。
+func (c *RTDSClient) SubscribeClobPriceChanges(tokenIDs []string, handler func(*RTDSClobPriceChange) error) error {
+	return c.subscribeTopic(RTDSTopicClobMarket, []string{RTDSTypePriceChange}, tokenIDs, payloadHandler(handler))
+}
+
+// SubscribeAggOrderbook 订阅资产的聚合订单簿（clob_market/agg_orderbook）。
+func (c *RTDSClient) SubscribeAggOrderbook(tokenIDs []string, handler func(*RTDSAggOrderbook) error) error {
+	return c.subscribeTopic(RTDSTopicClobMarket, []string{RTDSTypeAggOrderbook}, tokenIDs, payloadHandler(handler))
+}
+
+// SubscribeClobLastTradePrice 订阅资产的最新成交价（clob_market/last_trade_price）。
+func (c *RTDSClient) SubscribeClobLastTradePrice(tokenIDs []string, handler func(*RTDSClobLastTradePrice) error) error {
+	return c.subscribeTopic(RTDSTopicClobMarket, []string{RTDSTypeLastTradePrice}, tokenIDs, payloadHandler(handler))
+}
+
+// SubscribeClobTickSizeChange 订阅资产的最小价位变化（clob_market/tick_size_change）。
+func (c *RTDSClient) SubscribeClobTickSizeChange(tokenIDs []string, handler func(*RTDSClobTickSizeChange) error) error {
+	return c.subscribeTopic(RTDSTopicClobMarket, []string{RTDSTypeTickSizeChange}, tokenIDs, payloadHandler(handler))
+}
+
+// SubscribeMarketLifecycle 订阅市场创建与结算（msg.Type 区分 market_created / market_resolved）。
+func (c *RTDSClient) SubscribeMarketLifecycle(handler func(*RTDSMessage, *RTDSMarketLifecycle) error) error {
+	return c.subscribeTopic(RTDSTopicClobMarket, []string{RTDSTypeMarketCreated, RTDSTypeMarketResolved}, nil, RTDSHandler(handler))
+}
+
+// subscribeTopic 以同一 filters 订阅 topic 的多个类型；filters 编码为 JSON 字符串，零值时省略。
+func (c *RTDSClient) subscribeTopic(topic string, types []string, filters interface{}, handler RTDSMessageHandler) error {
+	encoded, err := rtdsFilters(filters)
+	if err != nil {
+		return err
+	}
+	details := make([]RTDSSubscriptionDetail, 0, len(types))
+	for _, t := range types {
+		details = append(details, RTDSSubscriptionDetail{Topic: topic, Type: t, Filters: encoded})
+	}
+	return c.subscribe(details, handler)
+}
+
+// rtdsFilters 把过滤条件编码为 RTDS 期望的 JSON 字符串。
+func rtdsFilters(v interface{}) (interface{}, error) {
+	switch f := v.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		if len(f) == 0 {
+			return nil, nil
+		}
+	case RTDSActivityFilter:
+		if f == (RTDSActivityFilter{}) {
+			return nil, nil
+		}
+	case RTDSCommentFilter:
+		if f == (RTDSCommentFilter{}) {
+			return nil, nil
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,202 @@
+// stream.go 模块
+package polymarket
+
+import (
+	"context"
+	"iter"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dcsunny/polymarket-sdk/internal/metrics"
+)
+
+// DefaultStreamBuffer 流式订阅的默认缓冲大小。
+const DefaultStreamBuffer = 256
+
+// OverflowPolicy 决定流式订阅缓冲区满时的行为。
+type OverflowPolicy int
+
+const (
+	// OverflowDropOldest 丢弃缓冲区中最旧的消息（默认）。
+	OverflowDropOldest OverflowPolicy = iota
+	// OverflowDropNewest 丢弃新到达的消息。
+	OverflowDropNewest
+	// OverflowBlock 不丢弃消息：缓冲区满时投递方等待消费者腾出空间。
+	// 投递方是连接的读循环（或连接池的分发器），因此消费者停滞会暂停该连接上的全部订阅与处理器，
+	// 直到消费者追上或流关闭；内存始终以 Buffer 为上限。
+	OverflowBlock
+)
+
+// StreamOptions 配置流式订阅。
+type StreamOptions struct {
+	// Buffer 缓冲大小（排队消息数上限）；0 使用 DefaultStreamBuffer。
+	Buffer int
+	// Overflow 缓冲区满时的策略（默认 OverflowDropOldest）。
+	Overflow OverflowPolicy
+	// Name 订阅名，作为丢弃计数指标的 subscription 标签；为空时为 "default"。
+	Name string
+}
+
+// Stream 是拉取式的事件流：通过 C() 在 select 中消费，或通过 All() 以 range 迭代。
+// 订阅的 ctx 结束、调用 Close 或底层客户端关闭后，C() 会被关闭。
+type Stream[T any] struct {
+	opts    StreamOptions
+	channel string
+	metrics Metrics
+
+	out    chan T
+	notify chan struct{}
+	space  chan struct{}
+	done   chan struct{}
+
+	mu      sync.Mutex
+	queue   []T
+	closed  bool
+	dropped atomic.Uint64
+
+	closeOnce sync.Once
+	onClose   func()
+}
+
+func newStream[T any](ctx context.Context, channel string, m Metrics, opts StreamOptions) *Stream[T] {
+	if opts.Buffer <= 0 {
+		opts.Buffer = DefaultStreamBuffer
+	}
+	if opts.Name == "" {
+		opts.Name = "default"
+	}
+	s := &Stream[T]{
+		opts:    opts,
+		channel: channel,
+		metrics: metrics.OrNop(m),
+		out:     make(chan T),
+		notify:  make(chan struct{}, 1),
+		space:   make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	go s.pump(ctx)
+	return s
+}
+
+// C 返回事件通道。
+func (s *Stream[T]) C() <-chan T {
+	return s.out
+}
+
+// All 返回事件迭代器，在流关闭后结束。
+func (s *Stream[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.out {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Dropped 返回因缓冲区溢出丢弃的消息数。
+func (s *Stream[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close 结束订阅。
+func (s *Stream[T]) Close() {
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		s.closed = true
+		s.queue = nil
+		s.mu.Unlock()
+		close(s.done)
+		if s.onClose != nil {
+			s.onClose()
+		}
+	})
+}
+
+// push 由读循环调用；只有 OverflowBlock 会在缓冲区满时阻塞，直到有空间或流关闭。
+func (s *Stream[T]) push(v T) {
+	s.mu.Lock()
+	for s.opts.Overflow == OverflowBlock && !s.closed && len(s.queue) >= s.opts.Buffer {
+		s.mu.Unlock()
+		select {
+		case <-s.space:
+		case <-s.done:
+		}
+		s.mu.Lock()
+	}
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	if len(s.queue) >= s.opts.Buffer {
+		switch s.opts.Overflow {
+		case OverflowDropNewest:
+			s.drop()
+			s.mu.Unlock()
+			return
+		default:
+			var zero T
+			s.queue[0] = zero
+			s.queue = s.queue[1:]
+			s.drop()
+		}
+	}
+	s.queue = append(s.queue, v)
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Stream[T]) drop() {
+	s.dropped.Add(1)
+	metrics.IncStreamDrop(s.metrics, s.channel, s.opts.Name)
+}
+
+// pump 在独立 goroutine 中把队列搬运到 out，消费者阻塞只影响本订阅。
+func (s *Stream[T]) pump(ctx context.Context) {
+	defer close(s.out)
+	for {
+		s.mu.Lock()
+		var (
+			v  T
+			ok bool
+		)
+		if len(s.queue) > 0 {
+			v, ok = s.queue[0], true
+			var zero T
+			s.queue[0] = zero
+			s.queue = s.queue[1:]
+		}
+		s.mu.Unlock()
+		if ok {
+			select {
+			case s.space <- struct{}{}:
+			default:
+			}
+		}
+
+		if !ok {
+			select {
+			case <-s.notify:
+				continue
+			case <-ctx.Done():
+				s.Close()
+				return
+			case <-s.done:
+				return
+			}
+		}
+
+		select {
+		case s.out <- v:
+		case <-ctx.Done():
+			s.Close()
+			return
+		case <-s.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// stream_test.go 模块
+package polymarket
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// baseMetrics 只实现 Metrics，不实现可选的 StreamDropMetrics。
+type baseMetrics struct{ Metrics }
+
+type dropMetrics struct {
+	baseMetrics
+	drops atomic.Int64
+}
+
+func (m *dropMetrics) IncStreamDrop(string, string) { m.drops.Add(1) }
+
+func queueLen[T any](s *Stream[T]) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+func TestStreamOverflow(t *testing.T) {
+	const buffer, pushes = 4, 20
+	tests := []struct {
+		name      string
+		policy    OverflowPolicy
+		wantFirst int
+	}{
+		{name: "default drops oldest", policy: OverflowPolicy(0), wantFirst: -1},
+		{name: "drop newest", policy: OverflowDropNewest, wantFirst: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			m := &dropMetrics{baseMetrics: baseMetrics{NopMetrics()}}
+			s := newStream[int](ctx, MetricsChannelMarket, m, StreamOptions{Buffer: buffer, Overflow: tt.policy})
+			defer s.Close()
+
+			for i := 0; i < pushes; i++ {
+				s.push(i)
+			}
+			if n := queueLen(s); n > buffer {
+				t.Fatalf("queue len = %d, want <= %d", n, buffer)
+			}
+			// pump 可能已取走一条在途消息。
+			if d := s.Dropped(); d < pushes-buffer-1 || d > pushes-buffer {
+				t.Fatalf("dropped = %d, want %d or %d", d, pushes-buffer-1, pushes-buffer)
+			}
+			if got := m.drops.Load(); uint64(got) != s.Dropped() {
+				t.Fatalf("metrics drops = %d, want %d", got, s.Dropped())
+			}
+
+			first := <-s.C()
+			if tt.wantFirst >= 0 && first != tt.wantFirst {
+				t.Fatalf("first = %d, want %d", first, tt.wantFirst)
+			}
+			// 丢弃最旧：除在途的 0 外，只保留最后 buffer 条。
+			if tt.wantFirst < 0 && first != 0 && first != pushes-buffer {
+				t.Fatalf("first = %d, want 0 or %d", first, pushes-buffer)
+			}
+		})
+	}
+}
+
+func TestStreamBlock(t *testing.T) {
+	const buffer, pushes = 2, 20
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newStream[int](ctx, MetricsChannelMarket, nil, StreamOptions{Buffer: buffer, Overflow: OverflowBlock})
+	defer s.Close()
+
+	var pushed atomic.Int32
+	go func() {
+		for i := 0; i < pushes; i++ {
+			s.push(i)
+			pushed.Add(1)
+		}
+	}()
+	time.Sleep(50 * time.Millisecond)
+	// 缓冲区 + pump 在途的一条之外，投递方应被阻塞。
+	if n := pushed.Load(); n > buffer+2 {
+		t.Fatalf("pushed %d without a consumer, want <= %d", n, buffer+2)
+	}
+	if n := queueLen(s); n > buffer {
+		t.Fatalf("queue len = %d, want <= %d", n, buffer)
+	}
+	for i := 0; i < pushes; i++ {
+		select {
+		case v := <-s.C():
+			if v != i {
+				t.Fatalf("got %d, want %d", v, i)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for %d", i)
+		}
+	}
+	if s.Dropped() != 0 {
+		t.Fatalf("dropped = %d, want 0", s.Dropped())
+	}
+
+	// 阻塞中的投递在流关闭后返回。
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < buffer+5; i++ {
+			s.push(i)
+		}
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	s.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("push still blocked after Close")
+	}
+}
+
+func TestStreamDropWithoutOptionalMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newStream[int](ctx, MetricsChannelMarket, baseMetrics{NopMetrics()}, StreamOptions{Buffer: 1})
+	for i := 0; i < 10; i++ {
+		s.push(i)
+	}
+	if s.Dropped() == 0 {
+		t.Fatal("expected drops")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-s.C():
+		for ok {
+			_, ok = <-s.C()
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stream not closed after ctx cancel")
+	}
+}
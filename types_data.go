@@ -0,0 +1,130 @@
+// types_data.go 模块
+package polymarket
+
+// Position represents a user position from the Data API.
+type Position struct {
+	ProxyWallet        string  `json:"proxyWallet"`
+	Asset              string  `json:"asset"`
+	ConditionID        string  `json:"conditionId"`
+	Size               float64 `json:"size"`
+	AvgPrice           float64 `json:"avgPrice"`
+	InitialValue       float64 `json:"initialValue"`
+	CurrentValue       float64 `json:"currentValue"`
+	CashPnl            float64 `json:"cashPnl"`
+	PercentPnl         float64 `json:"percentPnl"`
+	TotalBought        float64 `json:"totalBought"`
+	RealizedPnl        float64 `json:"realizedPnl"`
+	PercentRealizedPnl float64 `json:"percentRealizedPnl"`
+	CurPrice           float64 `json:"curPrice"`
+	Redeemable         bool    `json:"redeemable"`
+	Mergeable          bool    `json:"mergeable"`
+	Title              string  `json:"title"`
+	Slug               string  `json:"slug"`
+	Icon               string  `json:"icon"`
+	EventID            FlexInt `json:"eventId"`
+	EventSlug          string  `json:"eventSlug"`
+	Outcome            string  `json:"outcome"`
+	OutcomeIndex       int     `json:"outcomeIndex"`
+	OppositeOutcome    string  `json:"oppositeOutcome"`
+	OppositeAsset      string  `json:"oppositeAsset"`
+	EndDate            string  `json:"endDate"`
+	NegativeRisk       bool    `json:"negativeRisk"`
+}
+
+// Activity types.
+const (
+	ActivityTypeTrade      = "TRADE"
+	ActivityTypeSplit      = "SPLIT"
+	ActivityTypeMerge      = "MERGE"
+	ActivityTypeRedeem     = "REDEEM"
+	ActivityTypeReward     = "REWARD"
+	ActivityTypeConversion = "CONVERSION"
+)
+
+// Activity represents an on-chain activity record of a user.
+type Activity struct {
+	ProxyWallet     string  `json:"proxyWallet"`
+	Timestamp       int64   `json:"timestamp"`
+	ConditionID     string  `json:"conditionId"`
+	Type            string  `json:"type"`
+	Size            float64 `json:"size"`
+	UsdcSize        float64 `json:"usdcSize"`
+	TransactionHash string  `json:"transactionHash"`
+	Price           float64 `json:"price"`
+	Asset           string  `json:"asset"`
+	Side            string  `json:"side"`
+	OutcomeIndex    int     `json:"outcomeIndex"`
+	Title           string  `json:"title"`
+	Slug            string  `json:"slug"`
+	Icon            string  `json:"icon"`
+	EventSlug       string  `json:"eventSlug"`
+	Outcome         string  `json:"outcome"`
+
+	Name                  string `json:"name"`
+	Pseudonym             string `json:"pseudonym"`
+	Bio                   string `json:"bio"`
+	ProfileImage          string `json:"profileImage"`
+	ProfileImageOptimized string `json:"profileImageOptimized"`
+}
+
+// DataTrade represents a trade from the Data API.
+type DataTrade struct {
+	ProxyWallet     string  `json:"proxyWallet"`
+	Side            string  `json:"side"`
+	Asset           string  `json:"asset"`
+	ConditionID     string  `json:"conditionId"`
+	Size            float64 `json:"size"`
+	Price           float64 `json:"price"`
+	Timestamp       int64   `json:"timestamp"`
+	Title           string  `json:"title"`
+	Slug            string  `json:"slug"`
+	Icon            string  `json:"icon"`
+	EventSlug       string  `json:"eventSlug"`
+	Outcome         string  `json:"outcome"`
+	OutcomeIndex    int     `json:"outcomeIndex"`
+	TransactionHash string  `json:"transactionHash"`
+
+	Name                  string `json:"name"`
+	Pseudonym             string `json:"pseudonym"`
+	Bio                   string `json:"bio"`
+	ProfileImage          string `json:"profileImage"`
+	ProfileImageOptimized string `json:"profileImageOptimized"`
+}
+
+// MarketHolders lists the top holders of one token.
+type MarketHolders struct {
+	Token   string    `json:"token"`
+	Holders []*Holder `json:"holders"`
+}
+
+// Holder represents a token holder.
+type Holder struct {
+	ProxyWallet           string  `json:"proxyWallet"`
+	Asset                 string  `json:"asset"`
+	Amount                float64 `json:"amount"`
+	OutcomeIndex          int     `json:"outcomeIndex"`
+	Name                  string  `json:"name"`
+	Pseudonym             string  `json:"pseudonym"`
+	Bio                   string  `json:"bio"`
+	DisplayUsernamePublic bool    `json:"displayUsernamePublic"`
+	ProfileImage          string  `json:"profileImage"`
+	ProfileImageOptimized string  `json:"profileImageOptimized"`
+}
+
+// PortfolioValue is the total value of a user's positions.
+type PortfolioValue struct {
+	User  string  `json:"user"`
+	Value float64 `json:"value"`
+}
+
+// LeaderboardEntry represents one trader on the leaderboard.
+type LeaderboardEntry struct {
+	Rank          FlexInt `json:"rank"`
+	ProxyWallet   string  `json:"proxyWallet"`
+	UserName      string  `json:"userName"`
+	Volume        float64 `json:"vol"`
+	Pnl           float64 `json:"pnl"`
+	ProfileImage  string  `json:"profileImage"`
+	XUsername     string  `json:"xUsername"`
+	VerifiedBadge bool    `json:"verifiedBadge"`
+}
@@ -0,0 +1,268 @@
+// types_gamma.go 模块
+package polymarket
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tag represents a Gamma tag (category).
+type Tag struct {
+	ID          string     `json:"id"`
+	Label       string     `json:"label"`
+	Slug        string     `json:"slug"`
+	ForceShow   bool       `json:"forceShow"`
+	ForceHide   bool       `json:"forceHide"`
+	IsCarousel  bool       `json:"isCarousel"`
+	PublishedAt string     `json:"publishedAt"`
+	CreatedBy   FlexInt    `json:"createdBy"`
+	UpdatedBy   FlexInt    `json:"updatedBy"`
+	CreatedAt   *time.Time `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt"`
+}
+
+// RelatedTag represents a relationship between two tags.
+type RelatedTag struct {
+	ID           FlexInt `json:"id"`
+	TagID        FlexInt `json:"tagID"`
+	RelatedTagID FlexInt `json:"relatedTagID"`
+	Rank         int     `json:"rank"`
+}
+
+// Series represents a Gamma series (a recurring group of events, e.g. a league season).
+type Series struct {
+	ID          string `json:"id"`
+	Ticker      string `json:"ticker"`
+	Slug        string `json:"slug"`
+	Title       string `json:"title"`
+	Subtitle    string `json:"subtitle"`
+	SeriesType  string `json:"seriesType"`
+	Recurrence  string `json:"recurrence"`
+	Description string `json:"description"`
+	Image       string `json:"image"`
+	Icon        string `json:"icon"`
+	Layout      string `json:"layout"`
+
+	Active          bool `json:"active"`
+	Closed          bool `json:"closed"`
+	Archived        bool `json:"archived"`
+	New             bool `json:"new"`
+	Featured        bool `json:"featured"`
+	Restricted      bool `json:"restricted"`
+	IsTemplate      bool `json:"isTemplate"`
+	CommentsEnabled bool `json:"commentsEnabled"`
+
+	Volume       float64       `json:"volume"`
+	Volume24hr   float64       `json:"volume24hr"`
+	Liquidity    float64       `json:"liquidity"`
+	Competitive  FloatOrString `json:"competitive"`
+	Score        FloatOrString `json:"score"`
+	CommentCount int           `json:"commentCount"`
+
+	PythTokenID string `json:"pythTokenID"`
+	CGAssetName string `json:"cgAssetName"`
+
+	StartDate   *time.Time `json:"startDate"`
+	PublishedAt string     `json:"publishedAt"`
+	CreatedAt   *time.Time `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt"`
+
+	Events     []*Event      `json:"events"`
+	Tags       []*Tag        `json:"tags"`
+	Categories []interface{} `json:"categories"`
+}
+
+// Sport represents sport metadata (league) from Gamma.
+type Sport struct {
+	ID         FlexInt    `json:"id"`
+	Sport      string     `json:"sport"`
+	Image      string     `json:"image"`
+	Resolution string     `json:"resolution"`
+	Ordering   string     `json:"ordering"`
+	Tags       string     `json:"tags"`
+	Series     string     `json:"series"`
+	CreatedAt  *time.Time `json:"createdAt"`
+}
+
+// TagIDs returns the comma-separated Tags field as tag IDs.
+func (s *Sport) TagIDs() []int {
+	var ids []int
+	for _, v := range strings.Split(s.Tags, ",") {
+		if id, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Team represents a sports team.
+type Team struct {
+	ID           FlexInt    `json:"id"`
+	Name         string     `json:"name"`
+	League       string     `json:"league"`
+	Record       string     `json:"record"`
+	Logo         string     `json:"logo"`
+	Abbreviation string     `json:"abbreviation"`
+	Alias        string     `json:"alias"`
+	CreatedAt    *time.Time `json:"createdAt"`
+	UpdatedAt    *time.Time `json:"updatedAt"`
+}
+
+// SearchResults is the response of the Gamma public-search endpoint.
+type SearchResults struct {
+	Events     []*Event         `json:"events"`
+	Tags       []*SearchTag     `json:"tags"`
+	Profiles   []*SearchProfile `json:"profiles"`
+	Pagination SearchPagination `json:"pagination"`
+}
+
+// SearchPagination describes public-search paging state.
+type SearchPagination struct {
+	HasMore      bool `json:"hasMore"`
+	TotalResults int  `json:"totalResults"`
+}
+
+// SearchTag is a tag matched by public search.
+type SearchTag struct {
+	ID         string `json:"id"`
+	Label      string `json:"label"`
+	Slug       string `json:"slug"`
+	EventCount int    `json:"event_count"`
+}
+
+// SearchProfile is a user profile matched by public search.
+type SearchProfile struct {
+	ID                    string                 `json:"id"`
+	Name                  string                 `json:"name"`
+	Pseudonym             string                 `json:"pseudonym"`
+	DisplayUsernamePublic bool                   `json:"displayUsernamePublic"`
+	Bio                   string                 `json:"bio"`
+	ProxyWallet           string                 `json:"proxyWallet"`
+	ProfileImage          string                 `json:"profileImage"`
+	ProfileImageOptimized map[string]interface{} `json:"profileImageOptimized"`
+	WalletActivated       bool                   `json:"walletActivated"`
+	IsCloseOnly           bool                   `json:"isCloseOnly"`
+	IsCertReq             bool                   `json:"isCertReq"`
+	CreatedAt             *time.Time             `json:"createdAt"`
+	UpdatedAt             *time.Time             `json:"updatedAt"`
+}
+
+// Category represents a Gamma category.
+type Category struct {
+	ID             string     `json:"id"`
+	Label          string     `json:"label"`
+	ParentCategory string     `json:"parentCategory"`
+	Slug           string     `json:"slug"`
+	PublishedAt    string     `json:"publishedAt"`
+	CreatedBy      string     `json:"createdBy"`
+	UpdatedBy      string     `json:"updatedBy"`
+	CreatedAt      *time.Time `json:"createdAt"`
+	UpdatedAt      *time.Time `json:"updatedAt"`
+}
+
+// Collection represents a Gamma collection of events.
+type Collection struct {
+	ID             string     `json:"id"`
+	Ticker         string     `json:"ticker"`
+	Slug           string     `json:"slug"`
+	Title          string     `json:"title"`
+	Subtitle       string     `json:"subtitle"`
+	CollectionType string     `json:"collectionType"`
+	Description    string     `json:"description"`
+	Image          string     `json:"image"`
+	Icon           string     `json:"icon"`
+	HeaderImage    string     `json:"headerImage"`
+	Layout         string     `json:"layout"`
+	Active         bool       `json:"active"`
+	Closed         bool       `json:"closed"`
+	Archived       bool       `json:"archived"`
+	New            bool       `json:"new"`
+	Featured       bool       `json:"featured"`
+	Restricted     bool       `json:"restricted"`
+	IsTemplate     bool       `json:"isTemplate"`
+	PublishedAt    string     `json:"publishedAt"`
+	CreatedAt      *time.Time `json:"createdAt"`
+	UpdatedAt      *time.Time `json:"updatedAt"`
+}
+
+// Comment parent entity types.
+const (
+	CommentEntityEvent  = "Event"
+	CommentEntitySeries = "Series"
+	CommentEntityMarket = "market"
+)
+
+// Comment represents a Gamma comment.
+type Comment struct {
+	ID               string             `json:"id"`
+	Body             string             `json:"body"`
+	ParentEntityType string             `json:"parentEntityType"`
+	ParentEntityID   FlexInt            `json:"parentEntityID"`
+	ParentCommentID  string             `json:"parentCommentID"`
+	UserAddress      string             `json:"userAddress"`
+	ReplyAddress     string             `json:"replyAddress"`
+	CreatedAt        *time.Time         `json:"createdAt"`
+	UpdatedAt        *time.Time         `json:"updatedAt"`
+	Profile          *CommentProfile    `json:"profile"`
+	Reactions        []*CommentReaction `json:"reactions"`
+	ReportCount      int                `json:"reportCount"`
+	ReactionCount    int                `json:"reactionCount"`
+
+	// Replies is filled by NestComments; the API returns replies as a flat list
+	// linked by ParentCommentID.
+	Replies []*Comment `json:"replies,omitempty"`
+}
+
+// CommentProfile is the author profile attached to a comment or reaction.
+type CommentProfile struct {
+	Name                  string                 `json:"name"`
+	Pseudonym             string                 `json:"pseudonym"`
+	DisplayUsernamePublic bool                   `json:"displayUsernamePublic"`
+	Bio                   string                 `json:"bio"`
+	IsMod                 bool                   `json:"isMod"`
+	IsCreator             bool                   `json:"isCreator"`
+	ProxyWallet           string                 `json:"proxyWallet"`
+	BaseAddress           string                 `json:"baseAddress"`
+	ProfileImage          string                 `json:"profileImage"`
+	ProfileImageOptimized map[string]interface{} `json:"profileImageOptimized"`
+	Positions             []*CommentPosition     `json:"positions"`
+}
+
+// CommentPosition is a position held by a comment author (requested with get_positions).
+type CommentPosition struct {
+	TokenID      string        `json:"tokenId"`
+	PositionSize FloatOrString `json:"positionSize"`
+}
+
+// CommentReaction represents a reaction on a comment.
+type CommentReaction struct {
+	ID           string          `json:"id"`
+	CommentID    FlexInt         `json:"commentID"`
+	ReactionType string          `json:"reactionType"`
+	Icon         string          `json:"icon"`
+	UserAddress  string          `json:"userAddress"`
+	CreatedAt    *time.Time      `json:"createdAt"`
+	Profile      *CommentProfile `json:"profile"`
+}
+
+// PublicProfile represents a user's public Gamma profile.
+type PublicProfile struct {
+	ProxyWallet           string         `json:"proxyWallet"`
+	Name                  string         `json:"name"`
+	Pseudonym             string         `json:"pseudonym"`
+	DisplayUsernamePublic bool           `json:"displayUsernamePublic"`
+	Bio                   string         `json:"bio"`
+	ProfileImage          string         `json:"profileImage"`
+	XUsername             string         `json:"xUsername"`
+	VerifiedBadge         bool           `json:"verifiedBadge"`
+	Users                 []*ProfileUser `json:"users"`
+	CreatedAt             *time.Time     `json:"createdAt"`
+}
+
+// ProfileUser is an account linked to a public profile.
+type ProfileUser struct {
+	ID      string `json:"id"`
+	Creator bool   `json:"creator"`
+	Mod     bool   `json:"mod"`
+}
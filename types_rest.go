@@ -3,7 +3,11 @@ package polymarket
 
 import (
 	"encoding/json"
+	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -67,15 +71,18 @@ type Event struct {
 	Score        FloatOrString `json:"score"`
 	Recurrence   string        `json:"recurrence"`
 
-	Markets       []interface{} `json:"markets"`
-	Series        []interface{} `json:"series"`
-	Categories    []interface{} `json:"categories"`
-	Collections   []interface{} `json:"collections"`
-	Tags          []interface{} `json:"tags"`
+	Markets       []*Market     `json:"markets"`
+	Series        []*Series     `json:"series"`
+	Categories    []*Category   `json:"categories"`
+	Collections   []*Collection `json:"collections"`
+	Tags          []*Tag        `json:"tags"`
 	EventCreators []interface{} `json:"eventCreators"`
 	Chats         []interface{} `json:"chats"`
 	Templates     []interface{} `json:"templates"`
 	SubEvents     []interface{} `json:"subEvents"`
+
+	// Extra holds fields not modeled above, for forward compatibility.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // FloatOrString handles fields that can be string or number.
@@ -195,29 +202,34 @@ type Market struct {
 	OneMonthPriceChange float64 `json:"oneMonthPriceChange"`
 	OneWeekPriceChange  float64 `json:"oneWeekPriceChange"`
 	OneYearPriceChange  float64 `json:"oneYearPriceChange"`
-	OutcomePrices       string  `json:"outcomePrices"`
 
-	Competitive           interface{} `json:"competitive"`
-	GroupItemThreshold    interface{} `json:"groupItemThreshold"`
-	OrderMinSize          float64     `json:"orderMinSize"`
-	OrderPriceMinTickSize float64     `json:"orderPriceMinTickSize"`
-	RewardsMaxSpread      float64     `json:"rewardsMaxSpread"`
-	RewardsMinSize        float64     `json:"rewardsMinSize"`
-	Spread                float64     `json:"spread"`
+	// OutcomePrices is index-aligned with Outcomes and ClobTokenIds.
+	OutcomePrices FloatArray `json:"outcomePrices"`
+
+	Competitive           FloatOrString `json:"competitive"`
+	GroupItemThreshold    FloatOrString `json:"groupItemThreshold"`
+	OrderMinSize          float64       `json:"orderMinSize"`
+	OrderPriceMinTickSize float64       `json:"orderPriceMinTickSize"`
+	RewardsMaxSpread      float64       `json:"rewardsMaxSpread"`
+	RewardsMinSize        float64       `json:"rewardsMinSize"`
+	Spread                float64       `json:"spread"`
 
 	NegRisk      bool `json:"negRisk"`
 	NegRiskOther bool `json:"negRiskOther"`
 
-	Outcomes           string `json:"outcomes"`
-	ClobTokenIds       string `json:"clobTokenIds"`
-	QuestionID         string `json:"questionID"`
-	ResolutionSource   string `json:"resolutionSource"`
-	MarketMakerAddress string `json:"marketMakerAddress"`
+	Outcomes           StringArray `json:"outcomes"`
+	ClobTokenIds       StringArray `json:"clobTokenIds"`
+	QuestionID         string      `json:"questionID"`
+	ResolutionSource   string      `json:"resolutionSource"`
+	MarketMakerAddress string      `json:"marketMakerAddress"`
 
 	Events                []MarketEvent `json:"events"`
-	Categories            []interface{} `json:"categories"`
-	Tags                  []interface{} `json:"tags"`
-	UmaResolutionStatuses interface{}   `json:"umaResolutionStatuses"`
+	Categories            []*Category   `json:"categories"`
+	Tags                  []*Tag        `json:"tags"`
+	UmaResolutionStatuses StringArray   `json:"umaResolutionStatuses"`
+
+	// Extra holds fields not modeled above, for forward compatibility.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // MarketEvent represents event data attached to a market.
@@ -264,32 +276,253 @@ type MarketEvent struct {
 }
 
 // UnmarshalJSON handles Event ID that can be string or number.
+// The object is decoded once; keys that do not map to a field are kept in Extra.
 func (e *Event) UnmarshalJSON(data []byte) error {
-	type Alias Event
-	aux := &struct {
-		ID interface{} `json:"id"`
-		*Alias
-	}{
-		Alias: (*Alias)(e),
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for k, raw := range all {
+		if !strings.EqualFold(k, "id") {
+			continue
+		}
+		delete(all, k)
+		var id interface{}
+		if err := json.Unmarshal(raw, &id); err != nil {
+			return err
+		}
+		switch v := id.(type) {
+		case string:
+			e.IDRaw = v
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+				e.ID = n
+			}
+		case float64:
+			e.ID = int64(v)
+			e.IDRaw = strconv.FormatInt(int64(v), 10)
+		}
+	}
+	extra, err := decodeFields(all, e)
+	if err != nil {
+		return err
 	}
+	e.Extra = extra
+	return nil
+}
 
-	if err := json.Unmarshal(data, &aux); err != nil {
+// UnmarshalJSON decodes a market and keeps unknown fields in Extra.
+// The object is decoded once.
+func (m *Market) UnmarshalJSON(data []byte) error {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
 		return err
 	}
+	extra, err := decodeFields(all, m)
+	if err != nil {
+		return err
+	}
+	m.Extra = extra
+	return nil
+}
 
-	switch v := aux.ID.(type) {
-	case string:
-		e.IDRaw = v
-		if id, err := strconv.ParseInt(v, 10, 64); err == nil {
-			e.ID = id
+// OutcomeIndex returns the index of outcome (case-insensitive), or -1.
+func (m *Market) OutcomeIndex(outcome string) int {
+	for i, o := range m.Outcomes {
+		if strings.EqualFold(o, outcome) {
+			return i
 		}
-	case float64:
-		e.ID = int64(v)
-		e.IDRaw = strconv.FormatInt(int64(v), 10)
-	case int64:
-		e.ID = v
-		e.IDRaw = strconv.FormatInt(v, 10)
 	}
+	return -1
+}
 
+// TokenForOutcome returns the CLOB token ID of outcome, e.g. TokenForOutcome("Yes").
+func (m *Market) TokenForOutcome(outcome string) (string, bool) {
+	i := m.OutcomeIndex(outcome)
+	if i < 0 || i >= len(m.ClobTokenIds) {
+		return "", false
+	}
+	return m.ClobTokenIds[i], true
+}
+
+// PriceForOutcome returns the last outcome price of outcome.
+func (m *Market) PriceForOutcome(outcome string) (float64, bool) {
+	i := m.OutcomeIndex(outcome)
+	if i < 0 || i >= len(m.OutcomePrices) {
+		return 0, false
+	}
+	return m.OutcomePrices[i], true
+}
+
+// OutcomeForToken returns the outcome name of a CLOB token ID.
+func (m *Market) OutcomeForToken(tokenID string) (string, bool) {
+	for i, id := range m.ClobTokenIds {
+		if id == tokenID && i < len(m.Outcomes) {
+			return m.Outcomes[i], true
+		}
+	}
+	return "", false
+}
+
+// MarketForToken returns the event market that trades tokenID.
+func (e *Event) MarketForToken(tokenID string) *Market {
+	for _, m := range e.Markets {
+		for _, id := range m.ClobTokenIds {
+			if id == tokenID {
+				return m
+			}
+		}
+	}
 	return nil
 }
+
+// StringArray is a list of strings that Gamma encodes either as a JSON array
+// or as a string containing a JSON array (e.g. "[\"Yes\", \"No\"]").
+type StringArray []string
+
+// UnmarshalJSON accepts a JSON array, a string containing a JSON array, or a
+// plain comma-separated string. null and "" decode to nil.
+func (a *StringArray) UnmarshalJSON(data []byte) error {
+	raw, err := unwrapJSONString(data)
+	if err != nil || raw == nil {
+		*a = nil
+		return err
+	}
+	var out []string
+	if err := json.Unmarshal(raw, &out); err != nil {
+		if data[0] != '"' {
+			return err
+		}
+		// Tolerate plain comma-separated strings.
+		out = nil
+		for _, v := range strings.Split(string(raw), ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				out = append(out, v)
+			}
+		}
+	}
+	*a = out
+	return nil
+}
+
+// MarshalJSON encodes the list the way Gamma does: as a string containing a JSON array.
+func (a StringArray) MarshalJSON() ([]byte, error) {
+	return marshalJSONString([]string(a))
+}
+
+// FloatArray is a list of numbers that Gamma encodes as a string containing a
+// JSON array of numeric strings (e.g. "[\"0.55\", \"0.45\"]").
+type FloatArray []float64
+
+// UnmarshalJSON accepts a JSON array or a string containing a JSON array, whose
+// items may be numbers or numeric strings. null, "" and malformed values decode to nil.
+func (a *FloatArray) UnmarshalJSON(data []byte) error {
+	raw, err := unwrapJSONString(data)
+	if err != nil || raw == nil {
+		*a = nil
+		return err
+	}
+	var items []FloatOrString
+	if err := json.Unmarshal(raw, &items); err != nil {
+		// Like FloatOrString, malformed values decode to empty rather than failing the whole market.
+		*a = nil
+		return nil
+	}
+	out := make([]float64, len(items))
+	for i, v := range items {
+		out[i] = float64(v)
+	}
+	*a = out
+	return nil
+}
+
+// MarshalJSON encodes the list the way Gamma does: as a string containing a JSON array of strings.
+func (a FloatArray) MarshalJSON() ([]byte, error) {
+	if a == nil {
+		return marshalJSONString([]string(nil))
+	}
+	items := make([]string, len(a))
+	for i, v := range a {
+		items[i] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return marshalJSONString(items)
+}
+
+// unwrapJSONString returns the JSON array in data, decoding it first when it is
+// wrapped in a JSON string. It returns nil for null and empty strings.
+func unwrapJSONString(data []byte) ([]byte, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	if data[0] != '"' {
+		return data, nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if s = strings.TrimSpace(s); s == "" {
+		return nil, nil
+	}
+	return []byte(s), nil
+}
+
+func marshalJSONString(items []string) ([]byte, error) {
+	if items == nil {
+		return []byte("null"), nil
+	}
+	inner, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(inner))
+}
+
+var jsonFieldsCache sync.Map // reflect.Type -> map[string]int
+
+// decodeFields decodes the top-level values of a JSON object into the matching
+// fields of the struct dst points to, and returns the keys that do not map to a
+// field. Keys are matched case-insensitively, like encoding/json. It returns nil
+// extra when all keys are known.
+func decodeFields(all map[string]json.RawMessage, dst interface{}) (map[string]json.RawMessage, error) {
+	v := reflect.ValueOf(dst).Elem()
+	fields := jsonFields(v.Type())
+	var extra map[string]json.RawMessage
+	for k, raw := range all {
+		i, ok := fields[strings.ToLower(k)]
+		if !ok {
+			if extra == nil {
+				extra = make(map[string]json.RawMessage)
+			}
+			extra[k] = raw
+			continue
+		}
+		if err := json.Unmarshal(raw, v.Field(i).Addr().Interface()); err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", v.Type().Name(), k, err)
+		}
+	}
+	return extra, nil
+}
+
+// jsonFields maps the lower-cased JSON names of t's exported fields to their indexes.
+func jsonFields(t reflect.Type) map[string]int {
+	if v, ok := jsonFieldsCache.Load(t); ok {
+		return v.(map[string]int)
+	}
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = i
+	}
+	jsonFieldsCache.Store(t, fields)
+	return fields
+}
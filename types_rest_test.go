@@ -0,0 +1,118 @@
+// types_rest_test.go 模块
+package polymarket
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEventUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantID  int64
+		wantRaw string
+	}{
+		{"numeric id", `{"id": 123, "slug": "e"}`, 123, "123"},
+		{"string id", `{"id": "456", "slug": "e"}`, 456, "456"},
+		{"non numeric id", `{"ID": "abc", "slug": "e"}`, 0, "abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e Event
+			if err := json.Unmarshal([]byte(tt.data), &e); err != nil {
+				t.Fatal(err)
+			}
+			if e.ID != tt.wantID || e.IDRaw != tt.wantRaw || e.Slug != "e" {
+				t.Fatalf("got ID=%d IDRaw=%q Slug=%q", e.ID, e.IDRaw, e.Slug)
+			}
+			if e.Extra != nil {
+				t.Fatalf("unexpected Extra %v", e.Extra)
+			}
+		})
+	}
+}
+
+func TestMarketUnmarshalJSON(t *testing.T) {
+	data := `{
+		"id": "1",
+		"Question": "Will it?",
+		"outcomes": "[\"Yes\", \"No\"]",
+		"clobTokenIds": ["11", "22"],
+		"outcomePrices": "[\"0.55\", 0.45]",
+		"competitive": "0.9",
+		"newField": {"a": 1},
+		"events": [{"id": "9", "slug": "ev"}]
+	}`
+	var m Market
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.ID != "1" || m.Question != "Will it?" || m.Competitive != 0.9 {
+		t.Fatalf("scalar fields: %+v", m)
+	}
+	if !reflect.DeepEqual([]string(m.Outcomes), []string{"Yes", "No"}) ||
+		!reflect.DeepEqual([]string(m.ClobTokenIds), []string{"11", "22"}) ||
+		!reflect.DeepEqual([]float64(m.OutcomePrices), []float64{0.55, 0.45}) {
+		t.Fatalf("array fields: %v %v %v", m.Outcomes, m.ClobTokenIds, m.OutcomePrices)
+	}
+	if len(m.Events) != 1 || m.Events[0].Slug != "ev" {
+		t.Fatalf("events: %+v", m.Events)
+	}
+	if len(m.Extra) != 1 || string(m.Extra["newField"]) != `{"a": 1}` {
+		t.Fatalf("Extra = %v", m.Extra)
+	}
+
+	var bad Market
+	if err := json.Unmarshal([]byte(`{"negRisk": "yes"}`), &bad); err == nil {
+		t.Fatal("expected error for mistyped field")
+	}
+	var null Market
+	if err := json.Unmarshal([]byte(`null`), &null); err != nil || null.Extra != nil {
+		t.Fatalf("null: err=%v extra=%v", err, null.Extra)
+	}
+}
+
+func TestStringArrayUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		want []string
+	}{
+		{`["a","b"]`, []string{"a", "b"}},
+		{`"[\"a\", \"b\"]"`, []string{"a", "b"}},
+		{`"a, b"`, []string{"a", "b"}},
+		{`""`, nil},
+		{`null`, nil},
+	}
+	for _, tt := range tests {
+		var a StringArray
+		if err := json.Unmarshal([]byte(tt.data), &a); err != nil {
+			t.Fatalf("%s: %v", tt.data, err)
+		}
+		if !reflect.DeepEqual([]string(a), tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.data, a, tt.want)
+		}
+	}
+}
+
+func TestFloatArrayUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		want []float64
+	}{
+		{`[0.1, "0.2"]`, []float64{0.1, 0.2}},
+		{`"[\"0.55\", \"0.45\"]"`, []float64{0.55, 0.45}},
+		{`"not json"`, nil},
+		{`null`, nil},
+	}
+	for _, tt := range tests {
+		var a FloatArray
+		if err := json.Unmarshal([]byte(tt.data), &a); err != nil {
+			t.Fatalf("%s: %v", tt.data, err)
+		}
+		if !reflect.DeepEqual([]float64(a), tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.data, a, tt.want)
+		}
+	}
+}
@@ -11,9 +11,23 @@ type RTDSSubscription struct {
 
 // RTDSSubscriptionDetail represents a single subscription detail.
 type RTDSSubscriptionDetail struct {
-	Topic   string      `json:"topic"`
-	Type    string      `json:"type"`
-	Filters interface{} `json:"filters,omitempty"`
+	Topic     string         `json:"topic"`
+	Type      string         `json:"type"`
+	Filters   interface{}    `json:"filters,omitempty"`
+	ClobAuth  *RTDSClobAuth  `json:"clob_auth,omitempty"`
+	GammaAuth *RTDSGammaAuth `json:"gamma_auth,omitempty"`
+}
+
+// RTDSClobAuth represents CLOB API credentials for private topics (clob_user).
+type RTDSClobAuth struct {
+	Key        string `json:"key"`
+	Secret     string `json:"secret"`
+	Passphrase string `json:"passphrase"`
+}
+
+// RTDSGammaAuth represents Gamma auth for comment topics.
+type RTDSGammaAuth struct {
+	Address string `json:"address"`
 }
 
 // RTDSMessage represents a message received from RTDS.
@@ -41,3 +55,197 @@ const (
 
 // RTDSMessageHandler handles RTDS messages.
 type RTDSMessageHandler func(msg *RTDSMessage) error
+
+// RTDS topics.
+const (
+	RTDSTopicActivity   = "activity"
+	RTDSTopicComments   = "comments"
+	RTDSTopicRFQ        = "rfq"
+	RTDSTopicClobMarket = "clob_market"
+	RTDSTopicClobUser   = "clob_user"
+)
+
+// RTDS message types. RTDSTypeAll subscribes to every type of a topic.
+const (
+	RTDSTypeAll             = "*"
+	RTDSTypeUpdate          = "update"
+	RTDSTypeTrades          = "trades"
+	RTDSTypeOrdersMatched   = "orders_matched"
+	RTDSTypeCommentCreated  = "comment_created"
+	RTDSTypeCommentRemoved  = "comment_removed"
+	RTDSTypeReactionCreated = "reaction_created"
+	RTDSTypeReactionRemoved = "reaction_removed"
+	RTDSTypeRequestCreated  = "request_created"
+	RTDSTypeRequestEdited   = "request_edited"
+	RTDSTypeRequestCanceled = "request_canceled"
+	RTDSTypeRequestExpired  = "request_expired"
+	RTDSTypeQuoteCreated    = "quote_created"
+	RTDSTypeQuoteEdited     = "quote_edited"
+	RTDSTypeQuoteCanceled   = "quote_canceled"
+	RTDSTypeQuoteExpired    = "quote_expired"
+	RTDSTypePriceChange     = "price_change"
+	RTDSTypeAggOrderbook    = "agg_orderbook"
+	RTDSTypeLastTradePrice  = "last_trade_price"
+	RTDSTypeTickSizeChange  = "tick_size_change"
+	RTDSTypeMarketCreated   = "market_created"
+	RTDSTypeMarketResolved  = "market_resolved"
+	RTDSTypeOrder           = "order"
+	RTDSTypeTrade           = "trade"
+)
+
+// RTDSActivityFilter filters activity topics by event or market slug.
+type RTDSActivityFilter struct {
+	EventSlug  string `json:"event_slug,omitempty"`
+	MarketSlug string `json:"market_slug,omitempty"`
+}
+
+// RTDSCommentFilter filters comment topics by parent entity.
+type RTDSCommentFilter struct {
+	ParentEntityID   int64  `json:"parentEntityID,omitempty"`
+	ParentEntityType string `json:"parentEntityType,omitempty"`
+}
+
+// RTDSActivityTrade represents activity trades / orders_matched payload.
+type RTDSActivityTrade struct {
+	Asset           string  `json:"asset"`
+	Bio             string  `json:"bio"`
+	ConditionID     string  `json:"conditionId"`
+	EventSlug       string  `json:"eventSlug"`
+	Icon            string  `json:"icon"`
+	Name            string  `json:"name"`
+	Outcome         string  `json:"outcome"`
+	OutcomeIndex    int     `json:"outcomeIndex"`
+	Price           float64 `json:"price"`
+	ProfileImage    string  `json:"profileImage"`
+	ProxyWallet     string  `json:"proxyWallet"`
+	Pseudonym       string  `json:"pseudonym"`
+	Side            string  `json:"side"`
+	Size            float64 `json:"size"`
+	Slug            string  `json:"slug"`
+	Timestamp       FlexInt `json:"timestamp"`
+	Title           string  `json:"title"`
+	TransactionHash string  `json:"transactionHash"`
+}
+
+// RTDSCommentProfile represents the author profile attached to a comment.
+type RTDSCommentProfile struct {
+	BaseAddress           string `json:"baseAddress"`
+	DisplayUsernamePublic bool   `json:"displayUsernamePublic"`
+	Name                  string `json:"name"`
+	ProxyWallet           string `json:"proxyWallet"`
+	Pseudonym             string `json:"pseudonym"`
+}
+
+// RTDSComment represents comment_created / comment_removed payload.
+type RTDSComment struct {
+	ID               string             `json:"id"`
+	Body             string             `json:"body"`
+	ParentEntityType string             `json:"parentEntityType"`
+	ParentEntityID   FlexInt            `json:"parentEntityID"`
+	ParentCommentID  string             `json:"parentCommentID"`
+	UserAddress      string             `json:"userAddress"`
+	ReplyAddress     string             `json:"replyAddress"`
+	CreatedAt        string             `json:"createdAt"`
+	UpdatedAt        string             `json:"updatedAt"`
+	Profile          RTDSCommentProfile `json:"profile"`
+	ReactionCount    int                `json:"reactionCount"`
+	ReportCount      int                `json:"reportCount"`
+}
+
+// RTDSReaction represents reaction_created / reaction_removed payload.
+type RTDSReaction struct {
+	ID           string  `json:"id"`
+	CommentID    FlexInt `json:"commentID"`
+	ReactionType string  `json:"reactionType"`
+	Icon         string  `json:"icon"`
+	UserAddress  string  `json:"userAddress"`
+	CreatedAt    string  `json:"createdAt"`
+}
+
+// RTDSRFQRequest represents rfq request_* payload.
+type RTDSRFQRequest struct {
+	RequestID    string  `json:"requestId"`
+	ProxyAddress string  `json:"proxyAddress"`
+	Market       string  `json:"market"`
+	Token        string  `json:"token"`
+	Complement   string  `json:"complement"`
+	State        string  `json:"state"`
+	Side         string  `json:"side"`
+	SizeIn       float64 `json:"sizeIn"`
+	SizeOut      float64 `json:"sizeOut"`
+	Price        float64 `json:"price"`
+	Expiry       FlexInt `json:"expiry"`
+}
+
+// RTDSRFQQuote represents rfq quote_* payload.
+type RTDSRFQQuote struct {
+	QuoteID      string  `json:"quoteId"`
+	RequestID    string  `json:"requestId"`
+	ProxyAddress string  `json:"proxyAddress"`
+	Token        string  `json:"token"`
+	State        string  `json:"state"`
+	Side         string  `json:"side"`
+	SizeIn       float64 `json:"sizeIn"`
+	SizeOut      float64 `json:"sizeOut"`
+	Condition    string  `json:"condition"`
+	Complement   string  `json:"complement"`
+	Expiry       FlexInt `json:"expiry"`
+}
+
+// RTDSClobPriceChange represents clob_market price_change payload.
+type RTDSClobPriceChange struct {
+	Market       string               `json:"m"`
+	PriceChanges []RTDSClobPriceLevel `json:"pc"`
+	Timestamp    FlexInt              `json:"t"`
+}
+
+// RTDSClobPriceLevel represents a single level change in RTDSClobPriceChange.
+type RTDSClobPriceLevel struct {
+	AssetID string `json:"a"`
+	Hash    string `json:"h"`
+	Price   string `json:"p"`
+	Side    string `json:"s"`
+	Size    string `json:"si"`
+	BestAsk string `json:"ba"`
+	BestBid string `json:"bb"`
+}
+
+// RTDSAggOrderbook represents clob_market agg_orderbook payload.
+type RTDSAggOrderbook struct {
+	AssetID      string            `json:"asset_id"`
+	Market       string            `json:"market"`
+	Bids         []WSSOrderSummary `json:"bids"`
+	Asks         []WSSOrderSummary `json:"asks"`
+	Hash         string            `json:"hash"`
+	Timestamp    FlexInt           `json:"timestamp"`
+	MinOrderSize string            `json:"min_order_size"`
+	TickSize     string            `json:"tick_size"`
+	NegRisk      bool              `json:"neg_risk"`
+}
+
+// RTDSClobLastTradePrice represents clob_market last_trade_price payload.
+type RTDSClobLastTradePrice struct {
+	AssetID    string `json:"asset_id"`
+	Market     string `json:"market"`
+	FeeRateBps string `json:"fee_rate_bps"`
+	Price      string `json:"price"`
+	Side       string `json:"side"`
+	Size       string `json:"size"`
+}
+
+// RTDSClobTickSizeChange represents clob_market tick_size_change payload.
+type RTDSClobTickSizeChange struct {
+	Market      string   `json:"market"`
+	AssetIDs    []string `json:"asset_id"`
+	OldTickSize string   `json:"old_tick_size"`
+	NewTickSize string   `json:"new_tick_size"`
+}
+
+// RTDSMarketLifecycle represents clob_market market_created / market_resolved payload.
+type RTDSMarketLifecycle struct {
+	Market       string   `json:"market"`
+	AssetIDs     []string `json:"asset_ids"`
+	MinOrderSize string   `json:"min_order_size"`
+	TickSize     string   `json:"tick_size"`
+	NegRisk      bool     `json:"neg_risk"`
+}
@@ -4,6 +4,7 @@ package polymarket
 import (
 	"encoding/json"
 	"strconv"
+	"time"
 )
 
 // FlexInt can unmarshal from string or number.
@@ -39,6 +40,16 @@ const (
 	WSSChannelTypeMarket WSSChannelType = "market"
 )
 
+// WSS event types.
+const (
+	WSSEventBook           = "book"
+	WSSEventPriceChange    = "price_change"
+	WSSEventTickSizeChange = "tick_size_change"
+	WSSEventLastTradePrice = "last_trade_price"
+	WSSEventOrder          = "order"
+	WSSEventTrade          = "trade"
+)
+
 // WSSAuth represents auth for user channel.
 type WSSAuth struct {
 	APIKey     string `json:"apiKey"`
@@ -49,7 +60,8 @@ type WSSAuth struct {
 // WSSSubscription represents subscription request.
 type WSSSubscription struct {
 	Auth      *WSSAuth       `json:"auth,omitempty"`
-	Type      WSSChannelType `json:"type"`
+	Type      WSSChannelType `json:"type,omitempty"`
+	Operation string         `json:"operation,omitempty"`
 	Markets   []string       `json:"markets,omitempty"`
 	AssetsIDs []string       `json:"assets_ids,omitempty"`
 }
@@ -57,6 +69,23 @@ type WSSSubscription struct {
 // WSSMessageHandler handles raw message.
 type WSSMessageHandler func(data json.RawMessage) error
 
+// WSSErrorHandler receives handler and decode errors (eventType may be empty).
+type WSSErrorHandler func(eventType string, err error)
+
+// WSSUnknownHandler receives events of types the SDK does not model.
+type WSSUnknownHandler func(eventType string, data json.RawMessage)
+
+// WSSEvent is a single event delivered by the streaming API.
+type WSSEvent struct {
+	Channel string
+	Type    string
+	AssetID string
+	Market  string
+	// Data holds the decoded message (*WSSBookMessage, *WSSTradeEvent, ...); nil for unmodeled types.
+	Data any
+	Raw  json.RawMessage
+}
+
 // WSSTradeEvent represents a trade event from user channel.
 type WSSTradeEvent struct {
 	EventType    string          `json:"event_type"`
@@ -147,3 +176,29 @@ type WSSLastTradePriceMessage struct {
 	Side      string  `json:"side"`
 	FeeRate   string  `json:"fee_rate"`
 }
+
+// WSSConnectionState 表示 websocket 连接状态。
+type WSSConnectionState string
+
+const (
+	WSSStateConnecting   WSSConnectionState = "connecting"
+	WSSStateConnected    WSSConnectionState = "connected"
+	WSSStateDisconnected WSSConnectionState = "disconnected"
+	WSSStateResubscribed WSSConnectionState = "resubscribed"
+)
+
+// WSSConnectionEvent 描述一次连接状态变化。
+type WSSConnectionEvent struct {
+	Channel string
+	State   WSSConnectionState
+	// Attempt 为当前重连尝试次数（首次连接为 0）。
+	Attempt int
+	// Err 为断线原因或重连失败原因。
+	Err error
+	// StaleAssets 为 resubscribed 时仍需等待 book 快照的资产。
+	StaleAssets []string
+	Time        time.Time
+}
+
+// WSSConnectionHandler 处理连接状态事件。
+type WSSConnectionHandler func(event WSSConnectionEvent)
@@ -1,11 +1,16 @@
 // wallet_module.go 模块
 package polymarket
 
-import "context"
+import (
+	"context"
+
+	"github.com/dcsunny/polymarket-sdk/internal/httpx"
+)
 
 // WalletModule 提供使用 SDK 配置默认值的便捷构造函数。
 type WalletModule struct {
-	cfg Config
+	cfg   Config
+	clock Clock
 }
 
 func NewWalletModule(cfg Config) *WalletModule {
@@ -54,5 +59,11 @@ func (w *WalletModule) Relayer(ctx context.Context, cfg RelayerConfig) (*Relayer
 	if cfg.RelayerURL == "" {
 		cfg.RelayerURL = w.cfg.RelayerURL
 	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpx.NewHTTPClient(w.cfg.httpOptions(w.cfg.RelayerHTTP))
+	}
+	if cfg.BuilderAuth != nil && cfg.BuilderAuth.clock == nil && w.clock != nil {
+		cfg.BuilderAuth.SetClock(w.clock)
+	}
 	return NewRelayerClient(ctx, cfg)
 }
@@ -0,0 +1,224 @@
+// ws_conn.go 模块
+package polymarket
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// DefaultWSStaleTimeout WSS 默认的连接静默超时：超过该时间未收到任何消息（包括 PONG）即判定连接失效。
+	DefaultWSStaleTimeout = 30 * time.Second
+	// DefaultRTDSStaleTimeout RTDS 默认的连接静默超时（按 5 秒 ping 间隔取三个周期）。
+	DefaultRTDSStaleTimeout = 15 * time.Second
+
+	wsWriteTimeout  = 10 * time.Second
+	wsCloseTimeout  = 2 * time.Second
+	wsWriteQueueLen = 64
+	// wsMinWatchInterval 静默检测的最小轮询间隔，避免极小的超时值产生非正的 ticker 间隔。
+	wsMinWatchInterval = time.Millisecond
+)
+
+// ErrStaleConnection 表示连接在静默超时内未收到任何消息，已被强制断开。
+var ErrStaleConnection = errors.New("websocket connection stale")
+
+// errWSClosed 表示连接已关闭或正在关闭；写失败也以它包装返回（写失败会断开连接）。
+var errWSClosed = errors.New("websocket connection closed")
+
+// wsConn 包装 gorilla 连接：所有写操作经由单一写泵串行执行（gorilla 不允许并发写），
+// 并记录最近一次收到消息的时间供静默检测使用。
+type wsConn struct {
+	conn *websocket.Conn
+
+	out      chan wsFrame
+	stop     chan struct{}
+	readDone chan struct{}
+
+	lastRecv atomic.Int64
+
+	mu      sync.Mutex
+	failure error
+	closing bool
+
+	stopOnce sync.Once
+	readOnce sync.Once
+}
+
+type wsFrame struct {
+	kind int
+	data []byte
+	errc chan error
+}
+
+// newWSConn 启动写泵；staleTimeout > 0 时同时启动静默检测。
+func newWSConn(conn *websocket.Conn, staleTimeout time.Duration) *wsConn {
+	w := &wsConn{
+		conn:     conn,
+		out:      make(chan wsFrame, wsWriteQueueLen),
+		stop:     make(chan struct{}),
+		readDone: make(chan struct{}),
+	}
+	w.touch()
+	conn.SetPongHandler(func(string) error {
+		w.touch()
+		return nil
+	})
+	go w.pump()
+	if staleTimeout > 0 {
+		go w.watch(staleTimeout)
+	}
+	return w
+}
+
+// staleTimeout 解析配置：0 使用默认值 def，负数关闭静默检测。
+func staleTimeout(d, def time.Duration) time.Duration {
+	switch {
+	case d < 0:
+		return 0
+	case d == 0:
+		return def
+	}
+	return d
+}
+
+// read 读取下一条消息；连接因静默超时被断开时返回 ErrStaleConnection。
+func (w *wsConn) read() ([]byte, error) {
+	_, data, err := w.conn.ReadMessage()
+	if err != nil {
+		w.readOnce.Do(func() { close(w.readDone) })
+		w.mu.Lock()
+		if w.failure != nil {
+			err = w.failure
+		}
+		w.mu.Unlock()
+		return nil, err
+	}
+	w.touch()
+	return data, nil
+}
+
+// writeText 把文本消息交给写泵并等待写出结果。
+func (w *wsConn) writeText(data []byte) error {
+	return w.write(websocket.TextMessage, data)
+}
+
+// ping 发送应用层 "PING" 与协议层 ping 帧（后者由对端自动回复 pong）。
+func (w *wsConn) ping() {
+	if err := w.write(websocket.TextMessage, []byte("PING")); err != nil {
+		return
+	}
+	_ = w.write(websocket.PingMessage, nil)
+}
+
+func (w *wsConn) write(kind int, data []byte) error {
+	w.mu.Lock()
+	closing := w.closing
+	w.mu.Unlock()
+	if closing {
+		return errWSClosed
+	}
+	f := wsFrame{kind: kind, data: data, errc: make(chan error, 1)}
+	select {
+	case w.out <- f:
+	case <-w.stop:
+		return errWSClosed
+	}
+	select {
+	case err := <-f.errc:
+		if err != nil {
+			return fmt.Errorf("%w: %v", errWSClosed, err)
+		}
+		return nil
+	case <-w.stop:
+		return errWSClosed
+	}
+}
+
+func (w *wsConn) pump() {
+	for {
+		select {
+		case <-w.stop:
+			return
+		case f := <-w.out:
+			_ = w.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			err := w.conn.WriteMessage(f.kind, f.data)
+			f.errc <- err
+			if err != nil {
+				w.fail(err)
+				return
+			}
+		}
+	}
+}
+
+// watch 在静默超过 timeout/2 时主动发送 ping 帧探测，超过 timeout 仍未收到消息则断开连接，
+// 读循环随之退出并触发重连。轮询间隔为 timeout/4，且不小于 wsMinWatchInterval。
+func (w *wsConn) watch(timeout time.Duration) {
+	ticker := time.NewTicker(max(timeout/4, wsMinWatchInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			idle := w.idle()
+			if idle > timeout {
+				w.fail(ErrStaleConnection)
+				return
+			}
+			if idle > timeout/2 {
+				go func() { _ = w.write(websocket.PingMessage, nil) }()
+			}
+		}
+	}
+}
+
+func (w *wsConn) touch() {
+	w.lastRecv.Store(time.Now().UnixNano())
+}
+
+// idle 返回距最近一次收到消息的时间。
+func (w *wsConn) idle() time.Duration {
+	return time.Since(time.Unix(0, w.lastRecv.Load()))
+}
+
+// fail 记录首个失败原因并立即断开连接。
+func (w *wsConn) fail(err error) {
+	w.mu.Lock()
+	if w.failure == nil {
+		w.failure = err
+	}
+	w.mu.Unlock()
+	_ = w.close()
+}
+
+// close 立即断开连接（不发送 close 帧）。
+func (w *wsConn) close() error {
+	var err error
+	w.stopOnce.Do(func() {
+		close(w.stop)
+		err = w.conn.Close()
+	})
+	return err
+}
+
+// shutdown 优雅关闭：写完已排队的消息后发送 close 帧，等待对端确认（最多 wsCloseTimeout）再断开。
+// 调用方不应在读循环中调用（读循环需要继续读取以接收对端的 close 帧）。
+func (w *wsConn) shutdown() error {
+	msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	if err := w.write(websocket.CloseMessage, msg); err == nil {
+		w.mu.Lock()
+		w.closing = true
+		w.mu.Unlock()
+		select {
+		case <-w.readDone:
+		case <-time.After(wsCloseTimeout):
+		}
+	}
+	return w.close()
+}
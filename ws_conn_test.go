@@ -0,0 +1,138 @@
+// ws_conn_test.go 模块
+package polymarket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// dialTestWS 启动一个 websocket 服务端（serve 处理服务端连接）并返回已包装的客户端连接。
+func dialTestWS(t *testing.T, stale time.Duration, serve func(*websocket.Conn)) *wsConn {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		serve(conn)
+	}))
+	t.Cleanup(srv.Close)
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := newWSConn(conn, stale)
+	t.Cleanup(func() { _ = w.close() })
+	return w
+}
+
+func TestWSConnConcurrentWrites(t *testing.T) {
+	const writers, perWriter = 8, 50
+	got := make(chan string, writers*perWriter)
+	w := dialTestWS(t, -1, func(conn *websocket.Conn) {
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			got <- string(data)
+		}
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perWriter; j++ {
+				if err := w.writeText([]byte(fmt.Sprintf("%d-%d", i, j))); err != nil {
+					t.Errorf("write: %v", err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for len(seen) < writers*perWriter {
+		select {
+		case m := <-got:
+			seen[m] = true
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %d of %d messages", len(seen), writers*perWriter)
+		}
+	}
+
+	_ = w.close()
+	if err := w.writeText([]byte("late")); !errors.Is(err, errWSClosed) {
+		t.Fatalf("write after close = %v, want errWSClosed", err)
+	}
+}
+
+func TestWSConnStaleWatchdog(t *testing.T) {
+	const stale = 200 * time.Millisecond
+
+	t.Run("silent peer", func(t *testing.T) {
+		// 服务端既不发送也不读取（因此不回复 ping），连接应在超时后断开。
+		release := make(chan struct{})
+		defer close(release)
+		w := dialTestWS(t, stale, func(*websocket.Conn) { <-release })
+		start := time.Now()
+		_, err := w.read()
+		if !errors.Is(err, ErrStaleConnection) {
+			t.Fatalf("read err = %v, want ErrStaleConnection", err)
+		}
+		if d := time.Since(start); d < stale || d > 5*stale {
+			t.Fatalf("stale after %v, want about %v", d, stale)
+		}
+	})
+
+	t.Run("peer answers pings", func(t *testing.T) {
+		// 服务端持续读取，自动回复 watchdog 发出的 ping，连接不应被判定失效。
+		w := dialTestWS(t, stale, func(conn *websocket.Conn) {
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		})
+		done := make(chan error, 1)
+		go func() {
+			_, err := w.read()
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			t.Fatalf("connection dropped: %v", err)
+		case <-time.After(4 * stale):
+		}
+		_ = w.close()
+		if err := <-done; errors.Is(err, ErrStaleConnection) {
+			t.Fatalf("read err = %v after close, want non-stale", err)
+		}
+	})
+}
+
+func TestWSConnTinyStaleTimeout(t *testing.T) {
+	// 1–3ns 的超时会使 timeout/4 为 0，轮询间隔应被钳制而不是让 NewTicker panic。
+	for _, stale := range []time.Duration{1, 3} {
+		release := make(chan struct{})
+		w := dialTestWS(t, stale, func(*websocket.Conn) { <-release })
+		_, err := w.read()
+		close(release)
+		if !errors.Is(err, ErrStaleConnection) {
+			t.Fatalf("stale=%v read err = %v, want ErrStaleConnection", stale, err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// ws_dialer.go 模块
+package polymarket
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// DefaultWSHandshakeTimeout websocket 握手超时的默认值。
+const DefaultWSHandshakeTimeout = 45 * time.Second
+
+// WSDialerOptions 配置 WSS 与 RTDS 的 websocket 拨号。
+type WSDialerOptions struct {
+	// Proxy 代理地址，支持 http://（HTTP CONNECT）与 socks5://；
+	// 为空时沿用 Config.Proxy（scheme 不受支持时拨号报错），再为空时读取 HTTP(S)_PROXY 环境变量。
+	Proxy string
+	// TLSConfig 为空时沿用 Config.TLSConfig（或 Config.HTTPClient 的 TLS 设置）。
+	TLSConfig *tls.Config
+	// HandshakeTimeout 为 0 时使用 DefaultWSHandshakeTimeout。
+	HandshakeTimeout time.Duration
+	// Header 握手请求的额外头（Config.UserAgent 会作为默认 User-Agent）。
+	Header http.Header
+	// EnableCompression 协商 permessage-deflate 压缩。
+	EnableCompression bool
+
+	ReadBufferSize  int
+	WriteBufferSize int
+
+	// NetDialContext 自定义底层 TCP 拨号（可选，例如绑定出口网卡）。
+	NetDialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// wsDialer 按 Config 构建 websocket 拨号器与握手请求头。
+func (c Config) wsDialer() (*websocket.Dialer, http.Header, error) {
+	o := c.WSDialer
+	d := &websocket.Dialer{
+		Proxy:             http.ProxyFromEnvironment,
+		HandshakeTimeout:  o.HandshakeTimeout,
+		EnableCompression: o.EnableCompression,
+		ReadBufferSize:    o.ReadBufferSize,
+		WriteBufferSize:   o.WriteBufferSize,
+		NetDialContext:    o.NetDialContext,
+	}
+	if d.HandshakeTimeout == 0 {
+		d.HandshakeTimeout = DefaultWSHandshakeTimeout
+	}
+
+	tlsCfg := o.TLSConfig
+	if tlsCfg == nil {
+		tlsCfg = c.wsTLSConfig()
+	}
+	if tlsCfg != nil {
+		d.TLSClientConfig = tlsCfg.Clone()
+	}
+
+	proxy := o.Proxy
+	if proxy == "" {
+		// 未单独配置时沿用 Config.Proxy；其 scheme 不受支持时报错，避免静默绕过代理直连。
+		proxy = c.Proxy
+	}
+	if proxy != "" {
+		proxyURL, err := parseWSProxy(proxy)
+		if err != nil {
+			return nil, nil, err
+		}
+		d.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	header := o.Header.Clone()
+	if c.UserAgent != "" {
+		if header == nil {
+			header = http.Header{}
+		}
+		if header.Get("User-Agent") == "" {
+			header.Set("User-Agent", c.UserAgent)
+		}
+	}
+	return d, header, nil
+}
+
+// parseWSProxy 校验 websocket 代理地址；socks5h 视为 socks5（由代理端解析域名）。
+func parseWSProxy(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid websocket proxy %q: %w", raw, err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "socks5":
+	case "socks5h":
+		u.Scheme = "socks5"
+	default:
+		return nil, fmt.Errorf("unsupported websocket proxy scheme %q (want http or socks5)", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid websocket proxy %q: missing host", raw)
+	}
+	return u, nil
+}
@@ -5,118 +5,269 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"sort"
 	"sync"
 	"time"
 
-	"github.com/gorilla/websocket"
+	"github.com/dcsunny/polymarket-sdk/internal/metrics"
 )
 
 const (
 	wssPingInterval = 10 * time.Second
 )
 
+var errWSSNotConnected = errors.New("not connected")
+
 // WSSClient 处理 Polymarket WebSocket 订阅。
+//
+// market 与 user 频道是两条独立的连接（见 Market / User），各自维护订阅、处理器与生命周期，
+// 可以同时在线。每个频道维护当前订阅集合（可通过 AddAssets / RemoveAssets、AddMarkets / RemoveMarkets
+// 动态增减），连接断开后按 Config.WSSReconnect 自动重连并以完整订阅重放该集合；
+// 重连期间订阅过的资产会被标记为需要重新获取 book 快照（见 NeedsSnapshot）。
 type WSSClient struct {
-	cfg Config
-
-	mu       sync.RWMutex
-	conn     *websocket.Conn
-	handlers map[string]WSSMessageHandler
-
-	ctx    context.Context
-	cancel context.CancelFunc
+	market *WSSChannel
+	user   *WSSChannel
 }
 
 func NewWSSClient(cfg Config) *WSSClient {
-	ctx, cancel := context.WithCancel(context.Background())
 	return &WSSClient{
-		cfg:      cfg,
-		handlers: make(map[string]WSSMessageHandler),
-		ctx:      ctx,
-		cancel:   cancel,
+		market: newWSSChannel(cfg, WSSChannelTypeMarket, MetricsChannelMarket, cfg.WSSMarketURL),
+		user:   newWSSChannel(cfg, WSSChannelTypeUser, MetricsChannelUser, cfg.WSSUserURL),
 	}
 }
 
+// Market 返回 market 频道连接。
+func (c *WSSClient) Market() *WSSChannel {
+	return c.market
+}
+
+// User 返回 user 频道连接。
+func (c *WSSClient) User() *WSSChannel {
+	return c.user
+}
+
 // ConnectUserChannel 连接到用户频道。
 func (c *WSSClient) ConnectUserChannel() error {
-	return c.connect(c.cfg.WSSUserURL)
+	return c.user.Connect()
 }
 
 // ConnectMarketChannel 连接到市场频道。
 func (c *WSSClient) ConnectMarketChannel() error {
-	return c.connect(c.cfg.WSSMarketURL)
+	return c.market.Connect()
+}
+
+// SubscribeUserChannel 订阅用户事件（markets 并入 user 频道的订阅集合）。
+func (c *WSSClient) SubscribeUserChannel(markets []string, handlers map[string]WSSMessageHandler) error {
+	if _, err := c.user.auth(); err != nil {
+		return err
+	}
+	c.user.registerHandlers(handlers)
+	return c.user.subscribe(markets)
+}
+
+// SubscribeMarketChannel 订阅市场事件（assetIDs 并入 market 频道的订阅集合）。
+func (c *WSSClient) SubscribeMarketChannel(assetIDs []string, handlers map[string]WSSMessageHandler) error {
+	c.market.registerHandlers(handlers)
+	return c.market.subscribe(assetIDs)
+}
+
+// RegisterHandler 为事件类型注册处理器（同时作用于 market 与 user 频道；
+// 只需作用于单个频道时使用 Market().RegisterHandler / User().RegisterHandler）。
+func (c *WSSClient) RegisterHandler(eventType string, handler WSSMessageHandler) {
+	c.market.RegisterHandler(eventType, handler)
+	c.user.RegisterHandler(eventType, handler)
+}
+
+// OnConnectionState 为两个频道注册连接状态处理器（事件中的 Channel 区分频道）。
+func (c *WSSClient) OnConnectionState(handler WSSConnectionHandler) {
+	c.market.OnConnectionState(handler)
+	c.user.OnConnectionState(handler)
+}
+
+// NeedsSnapshot 判断资产在 market 频道重连后是否仍未收到新的 book 快照。
+func (c *WSSClient) NeedsSnapshot(assetID string) bool {
+	return c.market.NeedsSnapshot(assetID)
+}
+
+// StaleAssets 返回 market 频道重连后仍需等待 book 快照的资产。
+func (c *WSSClient) StaleAssets() []string {
+	return c.market.StaleAssets()
+}
+
+// Close 关闭两个频道的连接。
+func (c *WSSClient) Close() error {
+	return errors.Join(c.market.Close(), c.user.Close())
+}
+
+// WSSChannel 是单个频道（market 或 user）的 websocket 连接。
+type WSSChannel struct {
+	cfg      Config
+	kind     WSSChannelType
+	channel  string
+	endpoint string
+
+	mu       sync.RWMutex
+	conn     *wsConn
+	handlers map[string]WSSMessageHandler
+	typed    map[string][]func(any)
+	unknown  WSSUnknownHandler
+	onError  WSSErrorHandler
+	sinks    map[*wssSink]struct{}
+
+	subMu       sync.Mutex
+	ids         map[string]bool
+	active      bool
+	initialized bool
+	// streamRefs 记录由 StreamMarket 新增的资产被多少个流引用，引用归零时取消订阅。
+	streamRefs map[string]int
+
+	stale         map[string]bool
+	stateHandlers []WSSConnectionHandler
+
+	metrics Metrics
+	// tap 非空时接管解码后的消息（WSSPool 的分片把消息转交给合并的分发器）。
+	tap func(eventType, assetID, market string, msg json.RawMessage)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newWSSChannel(cfg Config, kind WSSChannelType, channel, endpoint string) *WSSChannel {
+	return &WSSChannel{
+		cfg:        cfg,
+		kind:       kind,
+		channel:    channel,
+		endpoint:   endpoint,
+		handlers:   make(map[string]WSSMessageHandler),
+		typed:      make(map[string][]func(any)),
+		sinks:      make(map[*wssSink]struct{}),
+		ids:        make(map[string]bool),
+		stale:      make(map[string]bool),
+		streamRefs: make(map[string]int),
+		metrics:    metrics.OrNop(cfg.Metrics),
+	}
 }
 
-func (c *WSSClient) connect(endpoint string) error {
-	if endpoint == "" {
+// Name 返回频道名（market / user）。
+func (c *WSSChannel) Name() string {
+	return c.channel
+}
+
+// Connected 判断当前是否持有连接。
+func (c *WSSChannel) Connected() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.conn != nil
+}
+
+// Connect 建立连接并发送当前订阅集合；已连接时替换为新连接。Close 之后可再次 Connect。
+func (c *WSSChannel) Connect() error {
+	if c.endpoint == "" {
 		return errors.New("endpoint is required")
 	}
-	conn, _, err := websocket.DefaultDialer.Dial(endpoint, nil)
+
+	c.mu.Lock()
+	if c.ctx == nil || c.ctx.Err() != nil {
+		c.ctx, c.cancel = context.WithCancel(context.Background())
+		go c.pingLoop(c.ctx)
+	}
+	ctx := c.ctx
+	c.mu.Unlock()
+
+	c.emitState(WSSConnectionEvent{Channel: c.channel, State: WSSStateConnecting})
+	conn, err := c.dial(ctx)
 	if err != nil {
+		c.emitState(WSSConnectionEvent{Channel: c.channel, State: WSSStateDisconnected, Err: err})
 		return err
 	}
 
 	c.mu.Lock()
 	if c.conn != nil {
-		_ = c.conn.Close()
+		_ = c.conn.close()
+		c.metrics.IncReconnect(c.channel)
 	}
 	c.conn = conn
+	c.initialized = false
 	c.mu.Unlock()
 
-	go c.readLoop()
-	go c.pingLoop()
-	return nil
+	c.emitState(WSSConnectionEvent{Channel: c.channel, State: WSSStateConnected})
+	go c.readLoop(ctx, conn)
+	return c.resubscribe()
 }
 
-// SubscribeUserChannel 订阅用户事件。
-func (c *WSSClient) SubscribeUserChannel(markets []string, handlers map[string]WSSMessageHandler) error {
-	if c.cfg.APIKey == "" || c.cfg.APISecret == "" || c.cfg.Passphrase == "" {
-		return errors.New("missing API credentials for user channel")
-	}
-	sub := WSSSubscription{
-		Auth: &WSSAuth{
-			APIKey:     c.cfg.APIKey,
-			Secret:     c.cfg.APISecret,
-			Passphrase: c.cfg.Passphrase,
-		},
-		Type:    WSSChannelTypeUser,
-		Markets: markets,
+func (c *WSSChannel) dial(ctx context.Context) (*wsConn, error) {
+	dialer, header, err := c.cfg.wsDialer()
+	if err != nil {
+		return nil, err
 	}
-	c.registerHandlers(handlers)
-	return c.send(sub)
-}
-
-// SubscribeMarketChannel 订阅市场事件。
-func (c *WSSClient) SubscribeMarketChannel(assetIDs []string, handlers map[string]WSSMessageHandler) error {
-	sub := WSSSubscription{
-		Type:      WSSChannelTypeMarket,
-		AssetsIDs: assetIDs,
+	conn, _, err := dialer.DialContext(ctx, c.endpoint, header)
+	if err != nil {
+		return nil, err
 	}
-	c.registerHandlers(handlers)
-	return c.send(sub)
+	return newWSConn(conn, staleTimeout(c.cfg.WSStaleTimeout, DefaultWSStaleTimeout)), nil
 }
 
 // RegisterHandler 为事件类型注册处理器。
-func (c *WSSClient) RegisterHandler(eventType string, handler WSSMessageHandler) {
+func (c *WSSChannel) RegisterHandler(eventType string, handler WSSMessageHandler) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.handlers[eventType] = handler
 }
 
-// Close 关闭连接。
-func (c *WSSClient) Close() error {
-	c.cancel()
+// OnConnectionState 注册连接状态处理器（connecting / connected / disconnected / resubscribed）。
+// 处理器在内部 goroutine 中同步调用，不应阻塞。
+func (c *WSSChannel) OnConnectionState(handler WSSConnectionHandler) {
+	if handler == nil {
+		return
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if c.conn == nil {
-		return nil
+	c.stateHandlers = append(c.stateHandlers, handler)
+}
+
+// NeedsSnapshot 判断资产在重连后是否仍未收到新的 book 快照（本地订单簿不可信）。
+func (c *WSSChannel) NeedsSnapshot(assetID string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.stale[assetID]
+}
+
+// StaleAssets 返回重连后仍需等待 book 快照的资产。
+func (c *WSSChannel) StaleAssets() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return sortedSet(c.stale)
+}
+
+// Close 停止重连并优雅关闭连接（写完已排队的消息、发送 close 帧并等待对端确认）；
+// 订阅集合会被清空，流式订阅随之结束。
+func (c *WSSChannel) Close() error {
+	c.mu.Lock()
+	if c.cancel != nil {
+		c.cancel()
 	}
-	err := c.conn.Close()
+	c.ids = make(map[string]bool)
+	c.active = false
+	c.initialized = false
+	c.stale = make(map[string]bool)
+	c.streamRefs = make(map[string]int)
+	sinks := c.sinks
+	c.sinks = make(map[*wssSink]struct{})
+	conn := c.conn
 	c.conn = nil
-	return err
+	c.mu.Unlock()
+
+	for k := range sinks {
+		k.stream.Close()
+	}
+	if conn == nil {
+		return nil
+	}
+	return conn.shutdown()
 }
 
-func (c *WSSClient) registerHandlers(handlers map[string]WSSMessageHandler) {
+func (c *WSSChannel) registerHandlers(handlers map[string]WSSMessageHandler) {
 	if handlers == nil {
 		return
 	}
@@ -127,38 +278,25 @@ func (c *WSSClient) registerHandlers(handlers map[string]WSSMessageHandler) {
 	}
 }
 
-func (c *WSSClient) send(msg interface{}) error {
+func (c *WSSChannel) send(msg interface{}) error {
 	c.mu.RLock()
 	conn := c.conn
 	c.mu.RUnlock()
 	if conn == nil {
-		return errors.New("not connected")
+		return errWSSNotConnected
 	}
 	data, err := json.Marshal(msg)
 	if err != nil {
 		return err
 	}
-	return conn.WriteMessage(websocket.TextMessage, data)
+	return conn.writeText(data)
 }
 
-func (c *WSSClient) readLoop() {
+func (c *WSSChannel) readLoop(ctx context.Context, conn *wsConn) {
 	for {
-		select {
-		case <-c.ctx.Done():
-			return
-		default:
-		}
-
-		c.mu.RLock()
-		conn := c.conn
-		c.mu.RUnlock()
-		if conn == nil {
-			time.Sleep(time.Second)
-			continue
-		}
-
-		_, message, err := conn.ReadMessage()
+		message, err := conn.read()
 		if err != nil {
+			c.handleDisconnect(ctx, conn, err)
 			return
 		}
 
@@ -172,6 +310,7 @@ func (c *WSSClient) readLoop() {
 		if message[0] == '[' {
 			var items []json.RawMessage
 			if err := json.Unmarshal(message, &items); err != nil {
+				c.reportError("", fmt.Errorf("decode message: %w", err))
 				continue
 			}
 			for _, item := range items {
@@ -184,37 +323,152 @@ func (c *WSSClient) readLoop() {
 	}
 }
 
-func (c *WSSClient) handleMessage(msg json.RawMessage) {
+// handleDisconnect 处理读循环退出：主动关闭或连接已被替换时直接返回，否则进入重连。
+func (c *WSSChannel) handleDisconnect(ctx context.Context, conn *wsConn, cause error) {
+	if ctx.Err() != nil {
+		return
+	}
+	c.mu.Lock()
+	if c.conn != conn {
+		c.mu.Unlock()
+		return
+	}
+	if c.kind == WSSChannelTypeMarket {
+		for id := range c.ids {
+			c.stale[id] = true
+		}
+	}
+	c.mu.Unlock()
+	_ = conn.close()
+
+	c.emitState(WSSConnectionEvent{Channel: c.channel, State: WSSStateDisconnected, Err: cause})
+	if c.cfg.WSSReconnect.Disabled {
+		return
+	}
+	go c.reconnect(ctx, conn)
+}
+
+// reconnect 按退避策略重连并重放订阅；期间若连接被手动替换或频道关闭则放弃。
+func (c *WSSChannel) reconnect(ctx context.Context, old *wsConn) {
+	policy := c.cfg.WSSReconnect
+	for attempt := 1; ; attempt++ {
+		if policy.exhausted(attempt) {
+			c.emitState(WSSConnectionEvent{Channel: c.channel, State: WSSStateDisconnected, Attempt: attempt - 1, Err: ErrReconnectExhausted})
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(policy.backoff(attempt)):
+		}
+
+		c.mu.RLock()
+		current := c.conn
+		c.mu.RUnlock()
+		if current != old {
+			return
+		}
+
+		c.emitState(WSSConnectionEvent{Channel: c.channel, State: WSSStateConnecting, Attempt: attempt})
+		conn, err := c.dial(ctx)
+		if err != nil {
+			c.emitState(WSSConnectionEvent{Channel: c.channel, State: WSSStateDisconnected, Attempt: attempt, Err: err})
+			continue
+		}
+
+		c.mu.Lock()
+		if c.conn != old || ctx.Err() != nil {
+			c.mu.Unlock()
+			_ = conn.close()
+			return
+		}
+		c.conn = conn
+		c.initialized = false
+		c.mu.Unlock()
+
+		c.metrics.IncReconnect(c.channel)
+		c.emitState(WSSConnectionEvent{Channel: c.channel, State: WSSStateConnected, Attempt: attempt})
+
+		go c.readLoop(ctx, conn)
+		if err := c.resubscribe(); err != nil {
+			// 写失败说明新连接也已断开，读循环会再次触发重连。
+			return
+		}
+		c.emitState(WSSConnectionEvent{Channel: c.channel, State: WSSStateResubscribed, Attempt: attempt, StaleAssets: c.StaleAssets()})
+		return
+	}
+}
+
+func (c *WSSChannel) emitState(event WSSConnectionEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	c.mu.RLock()
+	handlers := append([]WSSConnectionHandler(nil), c.stateHandlers...)
+	c.mu.RUnlock()
+	for _, h := range handlers {
+		h(event)
+	}
+}
+
+func (c *WSSChannel) handleMessage(msg json.RawMessage) {
 	var base struct {
 		EventType string `json:"event_type"`
+		AssetID   string `json:"asset_id"`
+		Market    string `json:"market"`
 	}
 	if err := json.Unmarshal(msg, &base); err != nil {
+		c.reportError("", fmt.Errorf("decode message: %w", err))
 		return
 	}
 
 	c.mu.RLock()
-	handler := c.handlers[base.EventType]
+	stale := base.EventType == WSSEventBook && c.stale[base.AssetID]
 	c.mu.RUnlock()
+	if stale {
+		c.mu.Lock()
+		delete(c.stale, base.AssetID)
+		c.mu.Unlock()
+	}
 
-	if handler != nil {
-		_ = handler(msg)
+	if c.tap != nil {
+		c.tap(base.EventType, base.AssetID, base.Market, msg)
+		return
 	}
+	c.deliver(base.EventType, base.AssetID, base.Market, msg)
+}
+
+// deliver 记录指标并分发消息。
+func (c *WSSChannel) deliver(eventType, assetID, market string, msg json.RawMessage) {
+	c.metrics.IncWSSMessage(c.channel, eventType)
+	start := time.Now()
+	c.dispatch(eventType, assetID, market, msg)
+	c.metrics.ObserveHandler(c.channel, eventType, time.Since(start))
 }
 
-func (c *WSSClient) pingLoop() {
+func (c *WSSChannel) pingLoop(ctx context.Context) {
 	ticker := time.NewTicker(wssPingInterval)
 	defer ticker.Stop()
 	for {
 		select {
-		case <-c.ctx.Done():
+		case <-ctx.Done():
 			return
 		case <-ticker.C:
 			c.mu.RLock()
 			conn := c.conn
 			c.mu.RUnlock()
 			if conn != nil {
-				_ = conn.WriteMessage(websocket.TextMessage, []byte("PING"))
+				conn.ping()
 			}
 		}
 	}
 }
+
+func sortedSet(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,180 @@
+// wss_dispatch.go 模块
+package polymarket
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// wssDecoders 为已建模的事件类型提供一次性解码。
+var wssDecoders = map[string]func(json.RawMessage) (any, error){
+	WSSEventBook:           decodeWSS[WSSBookMessage],
+	WSSEventPriceChange:    decodeWSS[WSSPriceChangeMessage],
+	WSSEventTickSizeChange: decodeWSS[WSSTickSizeChangeMessage],
+	WSSEventLastTradePrice: decodeWSS[WSSLastTradePriceMessage],
+	WSSEventOrder:          decodeWSS[WSSOrderEvent],
+	WSSEventTrade: func(data json.RawMessage) (any, error) {
+		v := new(WSSTradeEvent)
+		if err := json.Unmarshal(data, v); err != nil {
+			return nil, err
+		}
+		v.RawData = data
+		return v, nil
+	},
+}
+
+func decodeWSS[T any](data json.RawMessage) (any, error) {
+	v := new(T)
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// OnBook 注册 book 快照处理器。
+func (c *WSSChannel) OnBook(handler func(*WSSBookMessage)) {
+	onTyped(c, WSSEventBook, handler)
+}
+
+// OnPriceChange 注册 price_change 处理器。
+func (c *WSSChannel) OnPriceChange(handler func(*WSSPriceChangeMessage)) {
+	onTyped(c, WSSEventPriceChange, handler)
+}
+
+// OnTickSizeChange 注册 tick_size_change 处理器。
+func (c *WSSChannel) OnTickSizeChange(handler func(*WSSTickSizeChangeMessage)) {
+	onTyped(c, WSSEventTickSizeChange, handler)
+}
+
+// OnLastTradePrice 注册 last_trade_price 处理器。
+func (c *WSSChannel) OnLastTradePrice(handler func(*WSSLastTradePriceMessage)) {
+	onTyped(c, WSSEventLastTradePrice, handler)
+}
+
+// OnOrder 注册 order 事件处理器（user 频道）。
+func (c *WSSChannel) OnOrder(handler func(*WSSOrderEvent)) {
+	onTyped(c, WSSEventOrder, handler)
+}
+
+// OnTrade 注册 trade 事件处理器（user 频道）。
+func (c *WSSChannel) OnTrade(handler func(*WSSTradeEvent)) {
+	onTyped(c, WSSEventTrade, handler)
+}
+
+// OnUnknown 注册未建模事件类型的兜底处理器（仅在该类型没有 RegisterHandler 处理器时调用）。
+func (c *WSSChannel) OnUnknown(handler WSSUnknownHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unknown = handler
+}
+
+// OnError 注册错误处理器：接收处理器返回的错误与消息解码错误。
+func (c *WSSChannel) OnError(handler WSSErrorHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onError = handler
+}
+
+// onTyped 追加类型化处理器；同一事件类型可注册多个，按注册顺序调用。
+func onTyped[T any](c *WSSChannel, eventType string, handler func(*T)) {
+	if handler == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.typed[eventType] = append(c.typed[eventType], func(v any) { handler(v.(*T)) })
+}
+
+// dispatch 调用原始处理器、类型化处理器与流式订阅；已建模的消息只解码一次。
+func (c *WSSChannel) dispatch(eventType, assetID, market string, msg json.RawMessage) {
+	c.mu.RLock()
+	raw := c.handlers[eventType]
+	typed := c.typed[eventType]
+	unknown := c.unknown
+	var sinks []*wssSink
+	for k := range c.sinks {
+		if k.match(eventType, assetID, market) {
+			sinks = append(sinks, k)
+		}
+	}
+	c.mu.RUnlock()
+
+	if raw != nil {
+		if err := raw(msg); err != nil {
+			c.reportError(eventType, err)
+		}
+	}
+
+	decode, known := wssDecoders[eventType]
+	var v any
+	if known && (len(typed) > 0 || len(sinks) > 0) {
+		var err error
+		if v, err = decode(msg); err != nil {
+			c.reportError(eventType, fmt.Errorf("decode %s: %w", eventType, err))
+			return
+		}
+	}
+	for _, h := range typed {
+		h(v)
+	}
+	if len(sinks) > 0 {
+		ev := WSSEvent{Channel: c.channel, Type: eventType, AssetID: assetID, Market: market, Data: v, Raw: msg}
+		for _, k := range sinks {
+			k.stream.push(ev)
+		}
+	}
+	if !known && raw == nil && unknown != nil {
+		unknown(eventType, msg)
+	}
+}
+
+func (c *WSSChannel) reportError(eventType string, err error) {
+	c.mu.RLock()
+	h := c.onError
+	c.mu.RUnlock()
+	if h != nil {
+		h(eventType, err)
+	}
+}
+
+// OnBook 注册 market 频道 book 快照处理器。
+func (c *WSSClient) OnBook(handler func(*WSSBookMessage)) {
+	c.market.OnBook(handler)
+}
+
+// OnPriceChange 注册 market 频道 price_change 处理器。
+func (c *WSSClient) OnPriceChange(handler func(*WSSPriceChangeMessage)) {
+	c.market.OnPriceChange(handler)
+}
+
+// OnTickSizeChange 注册 market 频道 tick_size_change 处理器。
+func (c *WSSClient) OnTickSizeChange(handler func(*WSSTickSizeChangeMessage)) {
+	c.market.OnTickSizeChange(handler)
+}
+
+// OnLastTradePrice 注册 market 频道 last_trade_price 处理器。
+func (c *WSSClient) OnLastTradePrice(handler func(*WSSLastTradePriceMessage)) {
+	c.market.OnLastTradePrice(handler)
+}
+
+// OnOrder 注册 user 频道 order 事件处理器。
+func (c *WSSClient) OnOrder(handler func(*WSSOrderEvent)) {
+	c.user.OnOrder(handler)
+}
+
+// OnTrade 注册 user 频道 trade 事件处理器。
+func (c *WSSClient) OnTrade(handler func(*WSSTradeEvent)) {
+	c.user.OnTrade(handler)
+}
+
+// OnUnknown 为两个频道注册未建模事件类型的兜底处理器。
+func (c *WSSClient) OnUnknown(handler WSSUnknownHandler) {
+	c.market.OnUnknown(handler)
+	c.user.OnUnknown(handler)
+}
+
+// OnError 为两个频道注册错误处理器。
+func (c *WSSClient) OnError(handler WSSErrorHandler) {
+	c.market.OnError(handler)
+	c.user.OnError(handler)
+}
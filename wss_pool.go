@@ -0,0 +1,478 @@
+// wss_pool.go 模块
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWSSPoolShardSize 每条 market 连接默认承载的资产数上限。
+	DefaultWSSPoolShardSize = 500
+	// DefaultWSSPoolQueue 合并分发队列默认的排队消息数上限。
+	DefaultWSSPoolQueue = 4096
+)
+
+// WSSPoolOptions 配置 WSSPool。
+type WSSPoolOptions struct {
+	// MaxAssetsPerConn 每条连接的资产上限；0 使用 DefaultWSSPoolShardSize。
+	MaxAssetsPerConn int
+	// Queue 配置所有分片共用的分发队列（处理器与流式订阅落后时在此排队）：
+	// Buffer 为 0 时使用 DefaultWSSPoolQueue，溢出策略与 StreamOptions 相同（默认丢弃最旧），
+	// 丢弃计入 StreamDropMetrics（subscription 标签默认为 "pool"）。
+	Queue StreamOptions
+}
+
+// WSSShardStatus 是单个分片的健康状态快照。
+type WSSShardStatus struct {
+	// ID 分片编号，在分片生命周期内不变（分片回收后不复用）。
+	ID         int
+	Assets     int
+	Connected  bool
+	State      WSSConnectionState
+	Reconnects int
+	LastError  error
+	// Since 最近一次状态变化的时间。
+	Since       time.Time
+	StaleAssets []string
+}
+
+// WSSShardStateHandler 接收分片的连接状态事件。
+type WSSShardStateHandler func(shard int, event WSSConnectionEvent)
+
+// WSSPool 把大量资产订阅分摊到多条 market 频道连接上。
+//
+// 每条连接最多承载 MaxAssetsPerConn 个资产；新增资产放入负载最低且未满的分片，
+// 分片都满时新建连接；移除资产后会合并负载过低的分片并关闭多余连接。
+// 所有分片的事件进入同一个分发器按到达顺序串行分发，同一资产的事件保持顺序
+// （资产迁移分片后，旧分片上残留的该资产事件会被丢弃）。
+// 每个分片独立按 Config.WSSReconnect 重连，状态见 Shards / OnConnectionState。
+type WSSPool struct {
+	cfg     Config
+	maxConn int
+
+	// hub 不持有连接，只承载处理器、流式订阅与分发逻辑。
+	hub *WSSChannel
+
+	opMu sync.Mutex
+
+	mu            sync.RWMutex
+	shards        []*wssShard
+	owner         map[string]*wssShard
+	nextID        int
+	stateHandlers []WSSShardStateHandler
+	closed        bool
+
+	// queue 是有界的合并分发队列。
+	queue *Stream[wssPoolItem]
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type wssShard struct {
+	id     int
+	ch     *WSSChannel
+	assets int
+
+	// 以下字段由 WSSPool.mu 保护。
+	state      WSSConnectionState
+	reconnects int
+	lastErr    error
+	since      time.Time
+}
+
+type wssPoolItem struct {
+	eventType string
+	assetID   string
+	market    string
+	msg       json.RawMessage
+}
+
+// NewWSSPool 创建 market 频道连接池；连接在首次 AddAssets 时按需建立。
+func NewWSSPool(cfg Config, opts WSSPoolOptions) *WSSPool {
+	if opts.MaxAssetsPerConn <= 0 {
+		opts.MaxAssetsPerConn = DefaultWSSPoolShardSize
+	}
+	if opts.Queue.Buffer <= 0 {
+		opts.Queue.Buffer = DefaultWSSPoolQueue
+	}
+	if opts.Queue.Name == "" {
+		opts.Queue.Name = "pool"
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &WSSPool{
+		cfg:     cfg,
+		maxConn: opts.MaxAssetsPerConn,
+		hub:     newWSSChannel(cfg, WSSChannelTypeMarket, MetricsChannelMarket, ""),
+		owner:   make(map[string]*wssShard),
+		queue:   newStream[wssPoolItem](ctx, MetricsChannelMarket, cfg.Metrics, opts.Queue),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	go p.run()
+	return p
+}
+
+// AddAssets 订阅资产；已订阅的资产会被忽略。
+// 已有分片正在重连（或连接在发送时断开）时不返回错误：资产已记入该分片的订阅集合，
+// 重连成功后随完整订阅发送。新建分片连接失败时，分配给该分片的资产会被回滚并返回错误。
+func (p *WSSPool) AddAssets(assetIDs ...string) error {
+	p.opMu.Lock()
+	defer p.opMu.Unlock()
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return errors.New("pool closed")
+	}
+	plan := make(map[*wssShard][]string)
+	var fresh []*wssShard
+	for _, id := range assetIDs {
+		if id == "" || p.owner[id] != nil {
+			continue
+		}
+		s := p.leastLoaded(nil)
+		if s == nil {
+			s = p.newShard()
+			fresh = append(fresh, s)
+		}
+		p.owner[id] = s
+		s.assets++
+		plan[s] = append(plan[s], id)
+	}
+	p.mu.Unlock()
+
+	var errs []error
+	for _, s := range sortedShards(plan) {
+		if err := s.ch.Subscribe(plan[s]...); err != nil && !errors.Is(err, errWSClosed) && !errors.Is(err, errWSSNotConnected) {
+			errs = append(errs, fmt.Errorf("shard %d: %w", s.id, err))
+		}
+	}
+	for _, s := range fresh {
+		if err := s.ch.Connect(); err != nil {
+			errs = append(errs, fmt.Errorf("shard %d: %w", s.id, err))
+			p.dropShard(s)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RemoveAssets 取消订阅资产，并在需要时合并分片。
+func (p *WSSPool) RemoveAssets(assetIDs ...string) error {
+	p.opMu.Lock()
+	defer p.opMu.Unlock()
+
+	p.mu.Lock()
+	plan := make(map[*wssShard][]string)
+	for _, id := range assetIDs {
+		s := p.owner[id]
+		if s == nil {
+			continue
+		}
+		delete(p.owner, id)
+		s.assets--
+		plan[s] = append(plan[s], id)
+	}
+	p.mu.Unlock()
+
+	var errs []error
+	for _, s := range sortedShards(plan) {
+		if err := s.ch.Unsubscribe(plan[s]...); err != nil {
+			errs = append(errs, fmt.Errorf("shard %d: %w", s.id, err))
+		}
+	}
+	if err := p.rebalance(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Rebalance 把资产集中到所需的最少分片上，关闭空闲连接（RemoveAssets 会自动调用）。
+func (p *WSSPool) Rebalance() error {
+	p.opMu.Lock()
+	defer p.opMu.Unlock()
+	return p.rebalance()
+}
+
+// rebalance 调用方需持有 opMu。
+func (p *WSSPool) rebalance() error {
+	p.mu.Lock()
+	total := len(p.owner)
+	need := (total + p.maxConn - 1) / p.maxConn
+	plan := make(map[*wssShard][]string)
+	var retired []*wssShard
+	for len(p.shards) > need {
+		src := p.shards[0]
+		for _, s := range p.shards[1:] {
+			if s.assets < src.assets {
+				src = s
+			}
+		}
+		p.removeShard(src)
+		retired = append(retired, src)
+		for _, id := range p.assetsOf(src) {
+			dst := p.leastLoaded(src)
+			p.owner[id] = dst
+			dst.assets++
+			plan[dst] = append(plan[dst], id)
+		}
+	}
+	p.mu.Unlock()
+
+	// 先在目标分片订阅（所有权已转移，新的 book 快照会被分发），再关闭旧分片。
+	var errs []error
+	for _, s := range sortedShards(plan) {
+		if err := s.ch.Subscribe(plan[s]...); err != nil && !errors.Is(err, errWSClosed) && !errors.Is(err, errWSSNotConnected) {
+			errs = append(errs, fmt.Errorf("shard %d: %w", s.id, err))
+		}
+	}
+	for _, s := range retired {
+		_ = s.ch.Close()
+	}
+	return errors.Join(errs...)
+}
+
+// Assets 返回当前订阅的全部资产（已排序）。
+func (p *WSSPool) Assets() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	out := make([]string, 0, len(p.owner))
+	for id := range p.owner {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Shards 返回各分片的状态快照（按 ID 排序）。
+func (p *WSSPool) Shards() []WSSShardStatus {
+	p.mu.RLock()
+	shards := append([]*wssShard(nil), p.shards...)
+	out := make([]WSSShardStatus, 0, len(shards))
+	for _, s := range shards {
+		out = append(out, WSSShardStatus{
+			ID:         s.id,
+			Assets:     s.assets,
+			Connected:  s.state == WSSStateConnected || s.state == WSSStateResubscribed,
+			State:      s.state,
+			Reconnects: s.reconnects,
+			LastError:  s.lastErr,
+			Since:      s.since,
+		})
+	}
+	p.mu.RUnlock()
+	for i, s := range shards {
+		out[i].StaleAssets = s.ch.StaleAssets()
+	}
+	return out
+}
+
+// NeedsSnapshot 判断资产所在分片重连后是否仍未收到新的 book 快照。
+func (p *WSSPool) NeedsSnapshot(assetID string) bool {
+	p.mu.RLock()
+	s := p.owner[assetID]
+	p.mu.RUnlock()
+	return s != nil && s.ch.NeedsSnapshot(assetID)
+}
+
+// OnConnectionState 注册分片连接状态处理器；处理器在分片的内部 goroutine 中同步调用，不应阻塞。
+func (p *WSSPool) OnConnectionState(handler WSSShardStateHandler) {
+	if handler == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stateHandlers = append(p.stateHandlers, handler)
+}
+
+// RegisterHandler 为事件类型注册处理器。
+func (p *WSSPool) RegisterHandler(eventType string, handler WSSMessageHandler) {
+	p.hub.RegisterHandler(eventType, handler)
+}
+
+// OnBook 注册 book 快照处理器。
+func (p *WSSPool) OnBook(handler func(*WSSBookMessage)) {
+	p.hub.OnBook(handler)
+}
+
+// OnPriceChange 注册 price_change 处理器。
+func (p *WSSPool) OnPriceChange(handler func(*WSSPriceChangeMessage)) {
+	p.hub.OnPriceChange(handler)
+}
+
+// OnTickSizeChange 注册 tick_size_change 处理器。
+func (p *WSSPool) OnTickSizeChange(handler func(*WSSTickSizeChangeMessage)) {
+	p.hub.OnTickSizeChange(handler)
+}
+
+// OnLastTradePrice 注册 last_trade_price 处理器。
+func (p *WSSPool) OnLastTradePrice(handler func(*WSSLastTradePriceMessage)) {
+	p.hub.OnLastTradePrice(handler)
+}
+
+// OnUnknown 注册未建模事件类型的兜底处理器。
+func (p *WSSPool) OnUnknown(handler WSSUnknownHandler) {
+	p.hub.OnUnknown(handler)
+}
+
+// OnError 注册错误处理器（包括各分片的消息解码错误）。
+func (p *WSSPool) OnError(handler WSSErrorHandler) {
+	p.hub.OnError(handler)
+}
+
+// Stream 返回合并后的事件流（不订阅资产）；eventTypes 为空时接收全部事件类型。
+func (p *WSSPool) Stream(ctx context.Context, opts StreamOptions, eventTypes ...string) *Stream[WSSEvent] {
+	return p.hub.Stream(ctx, opts, eventTypes...)
+}
+
+// StreamAssets 订阅资产并返回只包含这些资产事件的流；ctx 结束时流关闭（资产保持订阅）。
+func (p *WSSPool) StreamAssets(ctx context.Context, assetIDs []string, opts StreamOptions) (*Stream[WSSEvent], error) {
+	s := p.hub.addSink(ctx, opts, &wssSink{assets: toSet(assetIDs)})
+	if err := p.AddAssets(assetIDs...); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close 关闭所有分片连接，流式订阅随之结束。
+func (p *WSSPool) Close() error {
+	p.opMu.Lock()
+	defer p.opMu.Unlock()
+
+	p.mu.Lock()
+	p.closed = true
+	shards := p.shards
+	p.shards = nil
+	p.owner = make(map[string]*wssShard)
+	p.mu.Unlock()
+
+	p.cancel()
+	var errs []error
+	for _, s := range shards {
+		errs = append(errs, s.ch.Close())
+	}
+	errs = append(errs, p.hub.Close())
+	return errors.Join(errs...)
+}
+
+// newShard 创建分片（尚未连接）；调用方需持有 mu。
+func (p *WSSPool) newShard() *wssShard {
+	s := &wssShard{id: p.nextID, since: time.Now()}
+	p.nextID++
+	ch := newWSSChannel(p.cfg, WSSChannelTypeMarket, MetricsChannelMarket, p.cfg.WSSMarketURL)
+	ch.tap = func(eventType, assetID, market string, msg json.RawMessage) {
+		p.forward(s, eventType, assetID, market, msg)
+	}
+	ch.OnError(p.hub.reportError)
+	ch.OnConnectionState(func(event WSSConnectionEvent) {
+		p.shardState(s, event)
+	})
+	s.ch = ch
+	p.shards = append(p.shards, s)
+	return s
+}
+
+// dropShard 回滚连接失败的新分片。
+func (p *WSSPool) dropShard(s *wssShard) {
+	p.mu.Lock()
+	p.removeShard(s)
+	for _, id := range p.assetsOf(s) {
+		delete(p.owner, id)
+	}
+	p.mu.Unlock()
+	_ = s.ch.Close()
+}
+
+// removeShard 调用方需持有 mu。
+func (p *WSSPool) removeShard(s *wssShard) {
+	for i, x := range p.shards {
+		if x == s {
+			p.shards = append(p.shards[:i], p.shards[i+1:]...)
+			return
+		}
+	}
+}
+
+// assetsOf 调用方需持有 mu。
+func (p *WSSPool) assetsOf(s *wssShard) []string {
+	var out []string
+	for id, owner := range p.owner {
+		if owner == s {
+			out = append(out, id)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// leastLoaded 返回负载最低且未满的分片（跳过 exclude）；调用方需持有 mu。
+func (p *WSSPool) leastLoaded(exclude *wssShard) *wssShard {
+	var best *wssShard
+	for _, s := range p.shards {
+		if s == exclude || s.assets >= p.maxConn {
+			continue
+		}
+		if best == nil || s.assets < best.assets {
+			best = s
+		}
+	}
+	return best
+}
+
+func (p *WSSPool) shardState(s *wssShard, event WSSConnectionEvent) {
+	p.mu.Lock()
+	s.state = event.State
+	s.since = event.Time
+	if event.Err != nil {
+		s.lastErr = event.Err
+	}
+	if event.State == WSSStateConnected && event.Attempt > 0 {
+		s.reconnects++
+	}
+	handlers := append([]WSSShardStateHandler(nil), p.stateHandlers...)
+	p.mu.Unlock()
+	for _, h := range handlers {
+		h(s.id, event)
+	}
+}
+
+// forward 把分片消息放入合并队列；资产已迁出该分片时丢弃。
+func (p *WSSPool) forward(s *wssShard, eventType, assetID, market string, msg json.RawMessage) {
+	if assetID != "" {
+		p.mu.RLock()
+		owned := p.owner[assetID] == s
+		p.mu.RUnlock()
+		if !owned {
+			return
+		}
+	}
+	p.queue.push(wssPoolItem{eventType: eventType, assetID: assetID, market: market, msg: msg})
+}
+
+// run 是合并分发器：按入队顺序串行分发所有分片的消息，直到连接池关闭。
+func (p *WSSPool) run() {
+	for it := range p.queue.C() {
+		p.hub.deliver(it.eventType, it.assetID, it.market, it.msg)
+	}
+}
+
+// Dropped 返回因合并分发队列溢出而丢弃的消息数。
+func (p *WSSPool) Dropped() uint64 {
+	return p.queue.Dropped()
+}
+
+func sortedShards(plan map[*wssShard][]string) []*wssShard {
+	out := make([]*wssShard, 0, len(plan))
+	for s := range plan {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].id < out[j].id })
+	return out
+}
@@ -0,0 +1,207 @@
+// wss_pool_test.go 模块
+package polymarket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// flakyWS 是可切换上线状态的 market 频道服务端，记录每条连接收到的资产订阅。
+type flakyWS struct {
+	*httptest.Server
+	up   atomic.Bool
+	subs chan []string
+	// msgs 记录收到的全部订阅消息（包括 user 频道与增量操作）。
+	msgs chan WSSSubscription
+
+	mu    sync.Mutex
+	conns []*websocket.Conn
+}
+
+func newFlakyWS(t *testing.T) *flakyWS {
+	f := &flakyWS{subs: make(chan []string, 16), msgs: make(chan WSSSubscription, 64)}
+	f.up.Store(true)
+	upgrader := websocket.Upgrader{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !f.up.Load() {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.conns = append(f.conns, conn)
+		f.mu.Unlock()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if string(data) == "PING" {
+				_ = conn.WriteMessage(websocket.TextMessage, []byte("PONG"))
+				continue
+			}
+			var sub WSSSubscription
+			if json.Unmarshal(data, &sub) != nil {
+				continue
+			}
+			select {
+			case f.msgs <- sub:
+			default:
+			}
+			if len(sub.AssetsIDs) > 0 {
+				f.subs <- sub.AssetsIDs
+			}
+		}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *flakyWS) url() string {
+	return "ws" + strings.TrimPrefix(f.URL, "http")
+}
+
+// down 拒绝新连接并断开现有连接。
+func (f *flakyWS) down() {
+	f.up.Store(false)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, c := range f.conns {
+		_ = c.Close()
+	}
+	f.conns = nil
+}
+
+func (f *flakyWS) waitSub(t *testing.T, want ...string) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case got := <-f.subs:
+			slices.Sort(got)
+			if slices.Equal(got, want) {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("timeout waiting for subscription %v", want)
+		}
+	}
+}
+
+func TestWSSPoolAddAssetsWhileReconnecting(t *testing.T) {
+	srv := newFlakyWS(t)
+	p := NewWSSPool(Config{
+		WSSMarketURL: srv.url(),
+		WSSReconnect: ReconnectPolicy{InitialBackoff: 20 * time.Millisecond, MaxBackoff: 50 * time.Millisecond},
+	}, WSSPoolOptions{MaxAssetsPerConn: 10})
+	defer p.Close()
+
+	if err := p.AddAssets("1"); err != nil {
+		t.Fatal(err)
+	}
+	srv.waitSub(t, "1")
+
+	srv.down()
+	deadline := time.Now().Add(5 * time.Second)
+	for p.Shards()[0].Connected {
+		if time.Now().After(deadline) {
+			t.Fatal("shard still connected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := p.AddAssets("2"); err != nil {
+		t.Fatalf("AddAssets while reconnecting: %v", err)
+	}
+	if got := p.Shards(); len(got) != 1 || got[0].Assets != 2 {
+		t.Fatalf("shards = %+v, want one shard with 2 assets", got)
+	}
+
+	srv.up.Store(true)
+	srv.waitSub(t, "1", "2")
+
+	// 连接已断开但读循环尚未察觉时，发送失败同样视为已排队。
+	p.mu.RLock()
+	ch := p.shards[0].ch
+	p.mu.RUnlock()
+	dead := &wsConn{stop: make(chan struct{})}
+	close(dead.stop)
+	ch.mu.Lock()
+	live := ch.conn
+	ch.conn = dead
+	ch.mu.Unlock()
+	err := p.AddAssets("3")
+	ch.mu.Lock()
+	ch.conn = live
+	ch.mu.Unlock()
+	if err != nil {
+		t.Fatalf("AddAssets on closing connection: %v", err)
+	}
+	if got := ch.Subscriptions(); !slices.Equal(got, []string{"1", "2", "3"}) {
+		t.Fatalf("subscriptions = %v", got)
+	}
+}
+
+func TestWSSPoolQueueBounded(t *testing.T) {
+	const buffer, pushes = 8, 100
+	m := &dropMetrics{baseMetrics: baseMetrics{NopMetrics()}}
+	p := NewWSSPool(Config{Metrics: m}, WSSPoolOptions{Queue: StreamOptions{Buffer: buffer}})
+	defer p.Close()
+
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var got []string
+	p.RegisterHandler("custom", func(data json.RawMessage) error {
+		<-release
+		mu.Lock()
+		got = append(got, string(data))
+		mu.Unlock()
+		return nil
+	})
+
+	// 处理器阻塞时，合并队列不超过 Buffer，溢出的最旧消息被丢弃并计入指标。
+	for i := 0; i < pushes; i++ {
+		p.forward(nil, "custom", "", "", json.RawMessage(strconv.Itoa(i)))
+	}
+	if n := queueLen(p.queue); n > buffer {
+		t.Fatalf("queue len = %d, want <= %d", n, buffer)
+	}
+	if d := p.Dropped(); d == 0 || uint64(m.drops.Load()) != d {
+		t.Fatalf("dropped = %d, metrics = %d", d, m.drops.Load())
+	}
+	close(release)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := len(got)
+		last := ""
+		if n > 0 {
+			last = got[n-1]
+		}
+		mu.Unlock()
+		if last == strconv.Itoa(pushes-1) {
+			if uint64(n)+p.Dropped() != pushes {
+				t.Fatalf("delivered %d + dropped %d != %d", n, p.Dropped(), pushes)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("delivered %d messages, last %q", n, last)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
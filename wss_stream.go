@@ -0,0 +1,165 @@
+// wss_stream.go 模块
+package polymarket
+
+import (
+	"context"
+	"fmt"
+)
+
+type wssSink struct {
+	types   map[string]bool
+	assets  map[string]bool
+	markets map[string]bool
+	stream  *Stream[WSSEvent]
+}
+
+// match 判断事件是否属于该订阅；各过滤集合为空时不过滤。
+func (k *wssSink) match(eventType, assetID, market string) bool {
+	if len(k.types) > 0 && !k.types[eventType] {
+		return false
+	}
+	if len(k.assets) > 0 && !k.assets[assetID] {
+		return false
+	}
+	if len(k.markets) > 0 && !k.markets[market] {
+		return false
+	}
+	return true
+}
+
+// Stream 返回该频道的事件流（不发送订阅消息）；eventTypes 为空时接收全部事件类型。
+func (c *WSSChannel) Stream(ctx context.Context, opts StreamOptions, eventTypes ...string) *Stream[WSSEvent] {
+	return c.addSink(ctx, opts, &wssSink{types: toSet(eventTypes)})
+}
+
+func (c *WSSChannel) addSink(ctx context.Context, opts StreamOptions, k *wssSink) *Stream[WSSEvent] {
+	k.stream = newStream[WSSEvent](ctx, c.channel, c.metrics, opts)
+	k.stream.onClose = func() {
+		c.mu.Lock()
+		delete(c.sinks, k)
+		c.mu.Unlock()
+	}
+	c.mu.Lock()
+	c.sinks[k] = struct{}{}
+	c.mu.Unlock()
+	return k.stream
+}
+
+// StreamMarket 订阅 market 频道资产，返回只包含这些资产事件的流；ctx 结束时流关闭。
+// 流关闭后，由流新增的资产在不再被其他流引用时取消订阅；调用前已订阅的资产保持订阅。
+func (c *WSSClient) StreamMarket(ctx context.Context, assetIDs []string, opts StreamOptions) (*Stream[WSSEvent], error) {
+	ch := c.market
+	owned := ch.retainStreamAssets(assetIDs)
+	s := ch.addSink(ctx, opts, &wssSink{assets: toSet(assetIDs)})
+	removeSink := s.onClose
+	s.onClose = func() {
+		removeSink()
+		ch.releaseStreamAssets(owned)
+	}
+	if err := ch.subscribe(assetIDs); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// retainStreamAssets 为流登记资产引用，返回由流持有的资产（尚未订阅或已由其他流持有）。
+func (c *WSSChannel) retainStreamAssets(ids []string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var owned []string
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if c.streamRefs[id] > 0 || !c.ids[id] {
+			c.streamRefs[id]++
+			owned = append(owned, id)
+		}
+	}
+	return owned
+}
+
+// releaseStreamAssets 释放流持有的资产引用，并取消订阅引用归零的资产。
+func (c *WSSChannel) releaseStreamAssets(ids []string) {
+	c.mu.Lock()
+	var drop []string
+	for _, id := range ids {
+		if c.streamRefs[id] == 0 {
+			continue
+		}
+		if c.streamRefs[id]--; c.streamRefs[id] == 0 {
+			delete(c.streamRefs, id)
+			drop = append(drop, id)
+		}
+	}
+	c.mu.Unlock()
+	if len(drop) == 0 {
+		return
+	}
+	if err := c.Unsubscribe(drop...); err != nil {
+		c.reportError("", fmt.Errorf("unsubscribe stream assets: %w", err))
+	}
+}
+
+// StreamUser 订阅 user 频道，返回事件流（markets 为空表示全部市场）；ctx 结束时流关闭。
+func (c *WSSClient) StreamUser(ctx context.Context, markets []string, opts StreamOptions) (*Stream[WSSEvent], error) {
+	if _, err := c.user.auth(); err != nil {
+		return nil, err
+	}
+	s := c.user.addSink(ctx, opts, &wssSink{markets: toSet(markets)})
+	if err := c.user.subscribe(markets); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Book 返回 book 快照（类型不符时为 nil）。
+func (e WSSEvent) Book() *WSSBookMessage {
+	v, _ := e.Data.(*WSSBookMessage)
+	return v
+}
+
+// PriceChange 返回 price_change 消息（类型不符时为 nil）。
+func (e WSSEvent) PriceChange() *WSSPriceChangeMessage {
+	v, _ := e.Data.(*WSSPriceChangeMessage)
+	return v
+}
+
+// TickSizeChange 返回 tick_size_change 消息（类型不符时为 nil）。
+func (e WSSEvent) TickSizeChange() *WSSTickSizeChangeMessage {
+	v, _ := e.Data.(*WSSTickSizeChangeMessage)
+	return v
+}
+
+// LastTradePrice 返回 last_trade_price 消息（类型不符时为 nil）。
+func (e WSSEvent) LastTradePrice() *WSSLastTradePriceMessage {
+	v, _ := e.Data.(*WSSLastTradePriceMessage)
+	return v
+}
+
+// Order 返回 order 事件（类型不符时为 nil）。
+func (e WSSEvent) Order() *WSSOrderEvent {
+	v, _ := e.Data.(*WSSOrderEvent)
+	return v
+}
+
+// Trade 返回 trade 事件（类型不符时为 nil）。
+func (e WSSEvent) Trade() *WSSTradeEvent {
+	v, _ := e.Data.(*WSSTradeEvent)
+	return v
+}
+
+func toSet(items []string) map[string]bool {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make(map[string]bool, len(items))
+	for _, v := range items {
+		out[v] = true
+	}
+	return out
+}
@@ -0,0 +1,181 @@
+// wss_subscription.go 模块
+package polymarket
+
+import (
+	"errors"
+)
+
+// WSS 动态订阅操作。
+const (
+	WSSOperationSubscribe   = "subscribe"
+	WSSOperationUnsubscribe = "unsubscribe"
+)
+
+// Subscribe 增量订阅：market 频道为资产 ID，user 频道为市场（condition ID）。
+// 已订阅的 ID 会被忽略；连接上首次订阅发送完整订阅消息，之后使用 operation=subscribe。
+// 订阅集合总是被更新：未连接时只记录，在下次 Connect / 重连时随完整订阅一起发送。
+func (c *WSSChannel) Subscribe(ids ...string) error {
+	return c.update(WSSOperationSubscribe, ids)
+}
+
+// Unsubscribe 增量取消订阅（operation=unsubscribe），并从订阅集合中移除。
+// 订阅集合因此变空时频道回到未订阅状态，重连时不再发送完整订阅
+// （user 频道空的 markets 表示“全部市场”，不能在重连时隐式订阅全部）。
+func (c *WSSChannel) Unsubscribe(ids ...string) error {
+	return c.update(WSSOperationUnsubscribe, ids)
+}
+
+// Subscriptions 返回当前订阅集合（已排序）。
+func (c *WSSChannel) Subscriptions() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return sortedSet(c.ids)
+}
+
+// subscribe 要求已连接，用于 SubscribeMarketChannel 等一次性订阅接口。
+func (c *WSSChannel) subscribe(ids []string) error {
+	if !c.Connected() {
+		return errWSSNotConnected
+	}
+	return c.update(WSSOperationSubscribe, ids)
+}
+
+// update 修改订阅集合并把变化发送到当前连接；subMu 保证完整订阅与增量操作按顺序写出。
+func (c *WSSChannel) update(op string, ids []string) error {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+
+	c.mu.Lock()
+	var changed []string
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		switch op {
+		case WSSOperationSubscribe:
+			if !c.ids[id] {
+				c.ids[id] = true
+				changed = append(changed, id)
+			}
+		case WSSOperationUnsubscribe:
+			if c.ids[id] {
+				delete(c.ids, id)
+				delete(c.stale, id)
+				changed = append(changed, id)
+			}
+		}
+	}
+	switch {
+	case op == WSSOperationSubscribe:
+		c.active = true
+	case len(changed) > 0 && len(c.ids) == 0:
+		c.active = false
+	}
+	conn := c.conn
+	initialized := c.initialized
+	c.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	if !initialized {
+		if op == WSSOperationUnsubscribe {
+			return nil
+		}
+		return c.sendInitial()
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	return c.send(c.operation(op, changed))
+}
+
+// resubscribe 在新连接上发送完整订阅（订阅集合为空且从未订阅时不发送）。
+func (c *WSSChannel) resubscribe() error {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+	return c.sendInitial()
+}
+
+// sendInitial 发送完整订阅消息；调用方需持有 subMu。
+func (c *WSSChannel) sendInitial() error {
+	c.mu.RLock()
+	ids := sortedSet(c.ids)
+	skip := !c.active || (c.kind == WSSChannelTypeMarket && len(ids) == 0)
+	c.mu.RUnlock()
+	if skip {
+		return nil
+	}
+	auth, err := c.auth()
+	if err != nil {
+		return err
+	}
+	sub := WSSSubscription{Auth: auth, Type: c.kind}
+	if c.kind == WSSChannelTypeUser {
+		sub.Markets = ids
+	} else {
+		sub.AssetsIDs = ids
+	}
+	if err := c.send(sub); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.initialized = true
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *WSSChannel) operation(op string, ids []string) WSSSubscription {
+	sub := WSSSubscription{Operation: op}
+	if c.kind == WSSChannelTypeUser {
+		sub.Markets = ids
+	} else {
+		sub.AssetsIDs = ids
+	}
+	return sub
+}
+
+// auth 返回 user 频道的认证信息；market 频道返回 nil。
+func (c *WSSChannel) auth() (*WSSAuth, error) {
+	if c.kind != WSSChannelTypeUser {
+		return nil, nil
+	}
+	if c.cfg.APIKey == "" || c.cfg.APISecret == "" || c.cfg.Passphrase == "" {
+		return nil, errors.New("missing API credentials for user channel")
+	}
+	return &WSSAuth{
+		APIKey:     c.cfg.APIKey,
+		Secret:     c.cfg.APISecret,
+		Passphrase: c.cfg.Passphrase,
+	}, nil
+}
+
+// AddAssets 在 market 频道上增量订阅资产。
+func (c *WSSClient) AddAssets(assetIDs ...string) error {
+	return c.market.Subscribe(assetIDs...)
+}
+
+// RemoveAssets 在 market 频道上取消订阅资产。
+func (c *WSSClient) RemoveAssets(assetIDs ...string) error {
+	return c.market.Unsubscribe(assetIDs...)
+}
+
+// Assets 返回 market 频道当前订阅的资产。
+func (c *WSSClient) Assets() []string {
+	return c.market.Subscriptions()
+}
+
+// AddMarkets 在 user 频道上增量订阅市场。
+func (c *WSSClient) AddMarkets(markets ...string) error {
+	return c.user.Subscribe(markets...)
+}
+
+// RemoveMarkets 在 user 频道上取消订阅市场。
+func (c *WSSClient) RemoveMarkets(markets ...string) error {
+	return c.user.Unsubscribe(markets...)
+}
+
+// Markets 返回 user 频道当前订阅的市场；为空时表示订阅了全部市场，或市场已全部移除（此时不再订阅）。
+func (c *WSSClient) Markets() []string {
+	return c.user.Subscriptions()
+}
@@ -0,0 +1,127 @@
+// wss_subscription_test.go 模块
+package polymarket
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func (f *flakyWS) nextMsg(t *testing.T) WSSSubscription {
+	t.Helper()
+	select {
+	case m := <-f.msgs:
+		return m
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for subscription message")
+		return WSSSubscription{}
+	}
+}
+
+func TestWSSUserUnsubscribeAllSkipsResubscribe(t *testing.T) {
+	srv := newFlakyWS(t)
+	c := NewWSSClient(Config{
+		WSSUserURL:   srv.url(),
+		APIKey:       "key",
+		APISecret:    "c2VjcmV0",
+		Passphrase:   "pass",
+		WSSReconnect: ReconnectPolicy{InitialBackoff: 20 * time.Millisecond, MaxBackoff: 50 * time.Millisecond},
+	}).User()
+	defer c.Close()
+	resubscribed := make(chan struct{}, 4)
+	c.OnConnectionState(func(e WSSConnectionEvent) {
+		if e.State == WSSStateResubscribed {
+			resubscribed <- struct{}{}
+		}
+	})
+
+	if err := c.Subscribe("m1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if m := srv.nextMsg(t); m.Operation != "" || !slices.Equal(m.Markets, []string{"m1"}) {
+		t.Fatalf("initial subscription = %+v", m)
+	}
+	if err := c.Unsubscribe("m1"); err != nil {
+		t.Fatal(err)
+	}
+	if m := srv.nextMsg(t); m.Operation != WSSOperationUnsubscribe || !slices.Equal(m.Markets, []string{"m1"}) {
+		t.Fatalf("unsubscribe = %+v", m)
+	}
+
+	srv.down()
+	time.Sleep(50 * time.Millisecond)
+	srv.up.Store(true)
+	select {
+	case <-resubscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("channel did not reconnect")
+	}
+
+	// 重连后不应发送 markets 为空（即“全部市场”）的完整订阅。
+	select {
+	case m := <-srv.msgs:
+		t.Fatalf("unexpected message after reconnect: %+v", m)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if err := c.Subscribe("m2"); err != nil {
+		t.Fatal(err)
+	}
+	if m := srv.nextMsg(t); m.Operation != "" || !slices.Equal(m.Markets, []string{"m2"}) {
+		t.Fatalf("subscription after reconnect = %+v", m)
+	}
+}
+
+func TestStreamMarketUnsubscribesOnTeardown(t *testing.T) {
+	srv := newFlakyWS(t)
+	c := NewWSSClient(Config{WSSMarketURL: srv.url()})
+	defer c.Close()
+
+	if err := c.Market().Subscribe("pre"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Market().Connect(); err != nil {
+		t.Fatal(err)
+	}
+	srv.nextMsg(t)
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	if _, err := c.StreamMarket(ctx1, []string{"pre", "a"}, StreamOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	srv.nextMsg(t)
+	if _, err := c.StreamMarket(ctx2, []string{"a", "b"}, StreamOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	srv.nextMsg(t)
+
+	waitSubscriptions := func(want ...string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !slices.Equal(c.Market().Subscriptions(), want) {
+			if time.Now().After(deadline) {
+				t.Fatalf("subscriptions = %v, want %v", c.Market().Subscriptions(), want)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// "a" 仍被第二个流引用，"pre" 在流之前已订阅，都应保留。
+	cancel1()
+	time.Sleep(100 * time.Millisecond)
+	waitSubscriptions("a", "b", "pre")
+
+	cancel2()
+	waitSubscriptions("pre")
+	m := srv.nextMsg(t)
+	slices.Sort(m.AssetsIDs)
+	if m.Operation != WSSOperationUnsubscribe || !slices.Equal(m.AssetsIDs, []string{"a", "b"}) {
+		t.Fatalf("unsubscribe = %+v", m)
+	}
+}